@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"github.com/mocksi/temporal-mcp/internal/sanitize_history_event"
+	temporal_enums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/history/v1"
+)
+
+// AnalyzeHistoryFileParams are the arguments of the AnalyzeHistoryFile tool
+type AnalyzeHistoryFileParams struct {
+	Path string `json:"path"`
+}
+
+// historyDigest summarizes a workflow history
+type historyDigest struct {
+	EventCount      int                        `json:"eventCount"`
+	WorkflowType    string                     `json:"workflowType,omitempty"`
+	TaskQueue       string                     `json:"taskQueue,omitempty"`
+	Status          string                     `json:"status"`
+	StartTime       *time.Time                 `json:"startTime,omitempty"`
+	LastEventTime   *time.Time                 `json:"lastEventTime,omitempty"`
+	Duration        string                     `json:"duration,omitempty"`
+	EventTypeCounts map[string]int             `json:"eventTypeCounts"`
+	Activities      map[string]*activityDigest `json:"activities,omitempty"`
+	Failures        []string                   `json:"failures,omitempty"`
+}
+
+// activityDigest counts what happened to the activities of one type
+type activityDigest struct {
+	Scheduled int `json:"scheduled"`
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+	TimedOut  int `json:"timedOut"`
+	Canceled  int `json:"canceled"`
+}
+
+// registerAnalyzeHistoryFileTool registers a tool that summarizes an exported history file. It doesn't need Temporal,
+// so it is available in degraded mode - e.g. for post-mortems on exported histories.
+func registerAnalyzeHistoryFileTool(server *mcp.Server, cfg *config.Config) error {
+	desc := fmt.Sprintf("Summarizes an exported workflow history file (JSONL, one event per line) without contacting Temporal: "+
+		"status, duration, event counts, activity outcomes and failure messages. path is relative to the %s directory",
+		cfg.HistoryFileDir)
+
+	return registerTool(server, cfg, "AnalyzeHistoryFile", desc, analyzeHistoryFileHandler(cfg.HistoryFileDir))
+}
+
+func analyzeHistoryFileHandler(dir string) func(args AnalyzeHistoryFileParams) (*mcp.ToolResponse, error) {
+	return func(args AnalyzeHistoryFileParams) (*mcp.ToolResponse, error) {
+		// OpenInRoot keeps the path from escaping the configured directory
+		f, err := os.OpenInRoot(dir, args.Path)
+		if err != nil {
+			msg := fmt.Sprintf("Error: Could not open history file %s: %v", args.Path, err)
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+		defer f.Close()
+
+		events, err := sanitize_history_event.ReadEvents(f)
+		if err != nil {
+			msg := fmt.Sprintf("Error: Could not read history file %s: %v", args.Path, err)
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		bytes, err := json.Marshal(summarizeHistory(events))
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResponse(mcp.NewTextContent(string(bytes))), nil
+	}
+}
+
+// summarizeHistory sanitizes the events and builds their digest
+func summarizeHistory(events []*history.HistoryEvent) historyDigest {
+	digest := historyDigest{
+		EventCount:      len(events),
+		Status:          "Running",
+		EventTypeCounts: map[string]int{},
+		Activities:      map[string]*activityDigest{},
+	}
+
+	// Activity completion events refer back to their scheduled event
+	activityTypes := map[int64]string{}
+	activity := func(scheduledEventID int64) *activityDigest {
+		name := activityTypes[scheduledEventID]
+		if name == "" {
+			name = "unknown"
+		}
+		if digest.Activities[name] == nil {
+			digest.Activities[name] = &activityDigest{}
+		}
+		return digest.Activities[name]
+	}
+
+	for _, event := range events {
+		sanitize_history_event.SanitizeHistoryEvent(event)
+		eventType := event.GetEventType()
+		digest.EventTypeCounts[eventType.String()]++
+
+		if event.GetEventTime() != nil {
+			eventTime := event.GetEventTime().AsTime()
+			if digest.StartTime == nil {
+				digest.StartTime = &eventTime
+			}
+			digest.LastEventTime = &eventTime
+		}
+		if isWorkflowCloseEvent(eventType) {
+			digest.Status = workflowStatusName(eventType)
+		}
+
+		switch eventType {
+		case temporal_enums.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED:
+			attrs := event.GetWorkflowExecutionStartedEventAttributes()
+			digest.WorkflowType = attrs.GetWorkflowType().GetName()
+			digest.TaskQueue = attrs.GetTaskQueue().GetName()
+		case temporal_enums.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
+			name := event.GetActivityTaskScheduledEventAttributes().GetActivityType().GetName()
+			activityTypes[event.GetEventId()] = name
+			activity(event.GetEventId()).Scheduled++
+		case temporal_enums.EVENT_TYPE_ACTIVITY_TASK_COMPLETED:
+			activity(event.GetActivityTaskCompletedEventAttributes().GetScheduledEventId()).Completed++
+		case temporal_enums.EVENT_TYPE_ACTIVITY_TASK_FAILED:
+			attrs := event.GetActivityTaskFailedEventAttributes()
+			activity(attrs.GetScheduledEventId()).Failed++
+			digest.addFailure(event, attrs.GetFailure().GetMessage())
+		case temporal_enums.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT:
+			activity(event.GetActivityTaskTimedOutEventAttributes().GetScheduledEventId()).TimedOut++
+		case temporal_enums.EVENT_TYPE_ACTIVITY_TASK_CANCELED:
+			activity(event.GetActivityTaskCanceledEventAttributes().GetScheduledEventId()).Canceled++
+		case temporal_enums.EVENT_TYPE_WORKFLOW_TASK_FAILED:
+			digest.addFailure(event, event.GetWorkflowTaskFailedEventAttributes().GetFailure().GetMessage())
+		case temporal_enums.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED:
+			digest.addFailure(event, event.GetWorkflowExecutionFailedEventAttributes().GetFailure().GetMessage())
+		}
+	}
+
+	if digest.StartTime != nil {
+		digest.Duration = digest.LastEventTime.Sub(*digest.StartTime).String()
+	}
+	if len(digest.Activities) == 0 {
+		digest.Activities = nil
+	}
+	return digest
+}
+
+// addFailure records a failure message along with the event it came from
+func (d *historyDigest) addFailure(event *history.HistoryEvent, message string) {
+	d.Failures = append(d.Failures, fmt.Sprintf("event %d (%s): %s",
+		event.GetEventId(), event.GetEventType(), message))
+}
+
+// workflowStatusName names the status a workflow close event leaves the workflow in, e.g. "Completed"
+func workflowStatusName(eventType temporal_enums.EventType) string {
+	return strings.TrimPrefix(eventType.String(), "WorkflowExecution")
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	temporal_enums "go.temporal.io/api/enums/v1"
+)
+
+func TestAnalyzeHistoryFile(t *testing.T) {
+	handler := analyzeHistoryFileHandler("test_data")
+
+	t.Run("digest", func(t *testing.T) {
+		resp, err := handler(AnalyzeHistoryFileParams{Path: "multi_step_history.jsonl"})
+		require.NoError(t, err)
+
+		var digest historyDigest
+		require.NoError(t, json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &digest))
+		require.Equal(t, 9, digest.EventCount)
+		require.Equal(t, "multi-step", digest.WorkflowType)
+		require.Equal(t, "tools", digest.TaskQueue)
+		require.Equal(t, "Running", digest.Status)
+		require.Equal(t, "36.054453s", digest.Duration)
+		require.Equal(t, 2, digest.EventTypeCounts["WorkflowTaskScheduled"])
+		require.Equal(t, 1, digest.EventTypeCounts["TimerFired"])
+		require.Equal(t, []string{"event 9 (WorkflowTaskFailed): [TMPRL1100] Nondeterminism error: Complete workflow machine " +
+			"does not handle this event: HistoryEvent(id: 5, TimerStarted)"}, digest.Failures)
+	})
+
+	t.Run("closed workflow status", func(t *testing.T) {
+		require.Equal(t, "Completed", workflowStatusName(temporal_enums.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED))
+		require.Equal(t, "ContinuedAsNew", workflowStatusName(temporal_enums.EVENT_TYPE_WORKFLOW_EXECUTION_CONTINUED_AS_NEW))
+	})
+
+	t.Run("paths can't escape the directory", func(t *testing.T) {
+		resp, err := asResponse(handler(AnalyzeHistoryFileParams{Path: "../main.go"}))
+		require.NoError(t, err)
+		require.Contains(t, resp.Content[0].TextContent.Text, "Error: Could not open history file")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		resp, err := asResponse(handler(AnalyzeHistoryFileParams{Path: "nope.jsonl"}))
+		require.NoError(t, err)
+		require.Contains(t, resp.Content[0].TextContent.Text, "Error: Could not open history file nope.jsonl")
+	})
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+)
+
+// Bounds of a BatchDescribeWorkflows call
+const (
+	maxBatchDescribeWorkflows = 100
+	batchDescribeConcurrency  = 8
+)
+
+// BatchDescribeWorkflowsParams are the arguments of the BatchDescribeWorkflows tool
+type BatchDescribeWorkflowsParams struct {
+	Workflows []WorkflowRef `json:"workflows"`
+}
+
+// WorkflowRef identifies a workflow run; an empty RunID means the latest run
+type WorkflowRef struct {
+	WorkflowID string `json:"workflowId"`
+	RunID      string `json:"runId,omitempty"`
+}
+
+// workflowStatusResult is one entry of the BatchDescribeWorkflows result. Error is set instead of the status fields
+// when the workflow couldn't be described.
+type workflowStatusResult struct {
+	WorkflowID   string     `json:"workflowId"`
+	RunID        string     `json:"runId,omitempty"`
+	WorkflowType string     `json:"workflowType,omitempty"`
+	Status       string     `json:"status,omitempty"`
+	StartTime    *time.Time `json:"startTime,omitempty"`
+	CloseTime    *time.Time `json:"closeTime,omitempty"`
+	Error        string     `json:"error,omitempty"`
+}
+
+// registerBatchDescribeWorkflowsTool registers a tool that gets the status of several workflows in one call
+func registerBatchDescribeWorkflowsTool(server *mcp.Server, tempClient client.Client, cfg *config.Config) error {
+	desc := fmt.Sprintf("Gets the status (type, status, start/close time) of up to %d workflows in one call. Each entry of "+
+		"workflows has a workflowId and an optional runId - if omitted, the %s run is described. Results are returned "+
+		"in the order requested; a workflow that can't be described has an error instead of a status",
+		maxBatchDescribeWorkflows, runSelector(cfg))
+
+	return registerTool(server, cfg, "BatchDescribeWorkflows", desc, batchDescribeWorkflowsHandler(tempClient, cfg))
+}
+
+func batchDescribeWorkflowsHandler(tempClient client.Client, cfg *config.Config) func(args BatchDescribeWorkflowsParams) (*mcp.ToolResponse, error) {
+	return func(args BatchDescribeWorkflowsParams) (*mcp.ToolResponse, error) {
+		// Check if Temporal client is available
+		if tempClient == nil {
+			log.Printf("Error: Temporal client is not available for describing workflows")
+			return degradedResponse("Error: Temporal client is not available for describing workflows")
+		}
+		if len(args.Workflows) == 0 {
+			return errorResponse("Error: workflows must list at least one workflow")
+		}
+		if len(args.Workflows) > maxBatchDescribeWorkflows {
+			return errorResponse(fmt.Sprintf(
+				"Error: %d workflows requested, but at most %d can be described in one call",
+				len(args.Workflows), maxBatchDescribeWorkflows,
+			))
+		}
+
+		results := make([]workflowStatusResult, len(args.Workflows))
+		sem := make(chan struct{}, batchDescribeConcurrency)
+		var wg sync.WaitGroup
+		for i, ref := range args.Workflows {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				results[i] = describeWorkflowStatus(context.Background(), tempClient, cfg, ref)
+			}()
+		}
+		wg.Wait()
+
+		bytes, err := json.Marshal(results)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResponse(mcp.NewTextContent(string(bytes))), nil
+	}
+}
+
+// describeWorkflowStatus describes one workflow, reporting a failure in the result's Error
+func describeWorkflowStatus(ctx context.Context, tempClient client.Client, cfg *config.Config, ref WorkflowRef) workflowStatusResult {
+	result := workflowStatusResult{WorkflowID: ref.WorkflowID, RunID: ref.RunID}
+	if ref.WorkflowID == "" {
+		result.Error = "workflowId is required"
+		return result
+	}
+
+	runID, err := resolveRun(ctx, tempClient, cfg, ref.WorkflowID, ref.RunID)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	resp, err := tempClient.DescribeWorkflowExecution(ctx, ref.WorkflowID, runID)
+	if err != nil {
+		var notFound *serviceerror.NotFound
+		if errors.As(err, &notFound) {
+			result.Error = "not found"
+		} else {
+			result.Error = err.Error()
+		}
+		log.Printf("Error describing workflow %s: %v", ref.WorkflowID, err)
+		return result
+	}
+
+	info := resp.GetWorkflowExecutionInfo()
+	result.RunID = info.GetExecution().GetRunId()
+	result.WorkflowType = info.GetType().GetName()
+	result.Status = info.GetStatus().String()
+	if info.GetStartTime() != nil {
+		startTime := info.GetStartTime().AsTime()
+		result.StartTime = &startTime
+	}
+	if info.GetCloseTime() != nil {
+		closeTime := info.GetCloseTime().AsTime()
+		result.CloseTime = &closeTime
+	}
+	return result
+}
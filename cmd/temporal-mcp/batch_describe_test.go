@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/common/v1"
+	temporal_enums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestBatchDescribeWorkflows(t *testing.T) {
+	started := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	describe := func(id string, status temporal_enums.WorkflowExecutionStatus, closed bool) *workflowservice.DescribeWorkflowExecutionResponse {
+		info := &workflow.WorkflowExecutionInfo{
+			Execution: &common.WorkflowExecution{WorkflowId: id, RunId: "run-" + id},
+			Type:      &common.WorkflowType{Name: "OrderWorkflow"},
+			Status:    status,
+			StartTime: timestamppb.New(started),
+		}
+		if closed {
+			info.CloseTime = timestamppb.New(started.Add(time.Minute))
+		}
+		return &workflowservice.DescribeWorkflowExecutionResponse{WorkflowExecutionInfo: info}
+	}
+	mockClient := &mockTemporalClient{describeResponses: map[string]*workflowservice.DescribeWorkflowExecutionResponse{
+		"order_1": describe("order_1", temporal_enums.WORKFLOW_EXECUTION_STATUS_COMPLETED, true),
+		"order_3": describe("order_3", temporal_enums.WORKFLOW_EXECUTION_STATUS_RUNNING, false),
+	}}
+
+	resp, err := batchDescribeWorkflowsHandler(mockClient, nil)(BatchDescribeWorkflowsParams{Workflows: []WorkflowRef{
+		{WorkflowID: "order_1"},
+		{WorkflowID: "order_2", RunID: "run-x"},
+		{WorkflowID: "order_3"},
+	}})
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"workflowId": "order_1", "runId": "run-order_1", "workflowType": "OrderWorkflow", "status": "Completed",
+		 "startTime": "2025-05-01T12:00:00Z", "closeTime": "2025-05-01T12:01:00Z"},
+		{"workflowId": "order_2", "runId": "run-x", "error": "not found"},
+		{"workflowId": "order_3", "runId": "run-order_3", "workflowType": "OrderWorkflow", "status": "Running",
+		 "startTime": "2025-05-01T12:00:00Z"}
+	]`, resp.Content[0].TextContent.Text)
+
+	t.Run("limits", func(t *testing.T) {
+		resp, err := asResponse(batchDescribeWorkflowsHandler(mockClient, nil)(BatchDescribeWorkflowsParams{}))
+		require.NoError(t, err)
+		require.Contains(t, resp.Content[0].TextContent.Text, "Error: workflows must list at least one workflow")
+
+		refs := make([]WorkflowRef, maxBatchDescribeWorkflows+1)
+		for i := range refs {
+			refs[i] = WorkflowRef{WorkflowID: fmt.Sprintf("order_%d", i)}
+		}
+		resp, err = asResponse(batchDescribeWorkflowsHandler(mockClient, nil)(BatchDescribeWorkflowsParams{Workflows: refs}))
+		require.NoError(t, err)
+		require.Contains(t, resp.Content[0].TextContent.Text, "at most 100 can be described in one call")
+	})
+}
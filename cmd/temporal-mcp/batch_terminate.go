@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"go.temporal.io/api/batch/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+)
+
+// batchTerminateIdentity is recorded as the identity on termination events
+const batchTerminateIdentity = "temporal-mcp"
+
+// BatchTerminateParams are the arguments of the BatchTerminate tool. Without confirm, the call is a dry run that only
+// counts the matching workflows; expectedCount must then repeat that count.
+type BatchTerminateParams struct {
+	Query         string `json:"query"`
+	Reason        string `json:"reason"`
+	Confirm       bool   `json:"confirm,omitempty"`
+	ExpectedCount int64  `json:"expectedCount,omitempty"`
+}
+
+// batchTerminateResult is the JSON returned by the BatchTerminate tool
+type batchTerminateResult struct {
+	Query    string `json:"query"`
+	Matching int64  `json:"matching"`
+	DryRun   bool   `json:"dryRun"`
+	JobID    string `json:"jobId,omitempty"`
+	Message  string `json:"message"`
+}
+
+// registerBatchTerminateTool registers a tool that terminates every workflow matching a visibility query
+func registerBatchTerminateTool(server *mcp.Server, tempClient client.Client, cfg *config.Config) error {
+	desc := "DESTRUCTIVE: terminates every running workflow matching a Temporal visibility query (e.g. `WorkflowType = " +
+		"'OrderWorkflow'`) via a server-side batch operation. Closed workflows are never counted or terminated - the " +
+		"query is narrowed to ExecutionStatus = 'Running'. Call it first without " +
+		"confirm - that only counts the matching workflows. Show the count to the user and, only once they explicitly " +
+		"approve, call again with confirm set to true and expectedCount set to that count. reason is required and is " +
+		"recorded on every terminated workflow"
+
+	return registerTool(server, cfg, "BatchTerminate", desc, batchTerminateHandler(tempClient, cfg))
+}
+
+func batchTerminateHandler(tempClient client.Client, cfg *config.Config) func(args BatchTerminateParams) (*mcp.ToolResponse, error) {
+	return func(args BatchTerminateParams) (*mcp.ToolResponse, error) {
+		// Check if Temporal client is available
+		if tempClient == nil {
+			log.Printf("Error: Temporal client is not available for terminating workflows")
+			return degradedResponse("Error: Temporal client is not available for terminating workflows")
+		}
+
+		// An empty query would match every workflow in the namespace
+		if strings.TrimSpace(args.Query) == "" {
+			return errorResponse("Error: query is required")
+		}
+		if strings.TrimSpace(args.Reason) == "" {
+			return errorResponse("Error: reason is required")
+		}
+
+		// Only running workflows can be terminated, so closed ones must not count towards expectedCount either
+		query := runningWorkflowsQuery(args.Query)
+
+		ctx := context.Background()
+		countResp, err := tempClient.CountWorkflow(ctx, &workflowservice.CountWorkflowExecutionsRequest{Query: query})
+		if err != nil {
+			msg := fmt.Sprintf("Error: Failed to count workflows matching the query: %v", err)
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+		result := batchTerminateResult{Query: query, Matching: countResp.GetCount()}
+
+		switch {
+		case !args.Confirm:
+			result.DryRun = true
+			result.Message = fmt.Sprintf("Dry run: %d workflows match. Nothing was terminated - to terminate them, call "+
+				"again with confirm: true and expectedCount: %d", result.Matching, result.Matching)
+		case args.ExpectedCount != result.Matching:
+			return errorResponse(fmt.Sprintf(
+				"Error: %d workflows match the query now, but expectedCount is %d - nothing was terminated. Run a dry run "+
+					"again and confirm the new count", result.Matching, args.ExpectedCount,
+			))
+		case result.Matching == 0:
+			result.Message = "No workflows match - nothing to terminate"
+		default:
+			result.JobID = uuid.NewString()
+			_, err := tempClient.WorkflowService().StartBatchOperation(ctx, &workflowservice.StartBatchOperationRequest{
+				Namespace:       temporalNamespace(cfg),
+				VisibilityQuery: query,
+				JobId:           result.JobID,
+				Reason:          args.Reason,
+				Operation: &workflowservice.StartBatchOperationRequest_TerminationOperation{
+					TerminationOperation: &batch.BatchOperationTermination{Identity: batchTerminateIdentity},
+				},
+			})
+			if err != nil {
+				msg := fmt.Sprintf("Error: Failed to start the batch termination: %v", err)
+				log.Print(msg)
+				return errorResponse(msg)
+			}
+			log.Printf("Started batch termination %s of %d workflows matching %q (reason: %q)", result.JobID,
+				result.Matching, query, args.Reason)
+			result.Message = fmt.Sprintf("Started terminating %d workflows; the batch job runs in the background on the "+
+				"Temporal server", result.Matching)
+		}
+
+		bytes, err := json.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResponse(mcp.NewTextContent(string(bytes))), nil
+	}
+}
+
+// runningWorkflowsQuery narrows a visibility query to running workflows
+func runningWorkflowsQuery(query string) string {
+	return "(" + query + ") AND ExecutionStatus = 'Running'"
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+func TestBatchTerminate(t *testing.T) {
+	const query = "WorkflowType = 'OrderWorkflow'"
+	// Closed workflows can't be terminated, so they are neither counted nor included in the batch
+	const running = "(WorkflowType = 'OrderWorkflow') AND ExecutionStatus = 'Running'"
+	cfg := &config.Config{Temporal: config.TemporalConfig{Namespace: "orders"}}
+	newClient := func() *mockTemporalClient {
+		return &mockTemporalClient{countResponse: &workflowservice.CountWorkflowExecutionsResponse{Count: 3}}
+	}
+	terminate := func(t *testing.T, mockClient *mockTemporalClient, args BatchTerminateParams) string {
+		resp, err := asResponse(batchTerminateHandler(mockClient, cfg)(args))
+		require.NoError(t, err)
+		return resp.Content[0].TextContent.Text
+	}
+
+	t.Run("dry run only counts", func(t *testing.T) {
+		mockClient := newClient()
+		var result batchTerminateResult
+		require.NoError(t, json.Unmarshal([]byte(terminate(t, mockClient, BatchTerminateParams{Query: query, Reason: "cleanup"})), &result))
+		require.True(t, result.DryRun)
+		require.Equal(t, int64(3), result.Matching)
+		require.Equal(t, running, mockClient.lastCountRequest.GetQuery())
+		require.Equal(t, running, result.Query)
+		require.Empty(t, mockClient.batchRequests)
+	})
+
+	t.Run("confirmed call starts a batch termination", func(t *testing.T) {
+		mockClient := newClient()
+		var result batchTerminateResult
+		text := terminate(t, mockClient, BatchTerminateParams{Query: query, Reason: "cleanup after incident", Confirm: true, ExpectedCount: 3})
+		require.NoError(t, json.Unmarshal([]byte(text), &result))
+		require.False(t, result.DryRun)
+
+		require.Len(t, mockClient.batchRequests, 1)
+		request := mockClient.batchRequests[0]
+		require.Equal(t, running, request.GetVisibilityQuery())
+		require.Equal(t, "cleanup after incident", request.GetReason())
+		require.Equal(t, "orders", request.GetNamespace())
+		require.Equal(t, result.JobID, request.GetJobId())
+		require.NotNil(t, request.GetTerminationOperation())
+	})
+
+	t.Run("count changed since the dry run", func(t *testing.T) {
+		mockClient := newClient()
+		text := terminate(t, mockClient, BatchTerminateParams{Query: query, Reason: "cleanup", Confirm: true, ExpectedCount: 2})
+		require.Contains(t, text, "Error: 3 workflows match the query now, but expectedCount is 2")
+		require.Empty(t, mockClient.batchRequests)
+	})
+
+	t.Run("query and reason are required", func(t *testing.T) {
+		mockClient := newClient()
+		require.Equal(t, "Error: query is required", terminate(t, mockClient, BatchTerminateParams{Reason: "cleanup", Confirm: true}))
+		require.Equal(t, "Error: reason is required", terminate(t, mockClient, BatchTerminateParams{Query: query, Confirm: true, ExpectedCount: 3}))
+		require.Empty(t, mockClient.batchRequests)
+	})
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+)
+
+// CountWorkflowsParams are the arguments of the CountWorkflows tool
+type CountWorkflowsParams struct {
+	Query string `json:"query"`
+}
+
+// countWorkflowsResult is the JSON returned by the CountWorkflows tool
+type countWorkflowsResult struct {
+	Count  int64                 `json:"count"`
+	Groups []countWorkflowsGroup `json:"groups,omitempty"`
+}
+
+// countWorkflowsGroup is one GROUP BY bucket of a count
+type countWorkflowsGroup struct {
+	Values []interface{} `json:"values"`
+	Count  int64         `json:"count"`
+}
+
+// registerCountWorkflowsTool registers a tool that counts the workflows matching a visibility query
+func registerCountWorkflowsTool(server *mcp.Server, tempClient client.Client, cfg *config.Config) error {
+	desc := "Counts the workflow executions matching a Temporal visibility query (e.g. `WorkflowType = 'OrderWorkflow' AND " +
+		"ExecutionStatus = 'Running'`) without listing them. An empty query counts all workflows. Add `GROUP BY ExecutionStatus` " +
+		"to get per-status counts. Requires advanced visibility on the Temporal server"
+
+	return registerTool(server, cfg, "CountWorkflows", desc, countWorkflowsHandler(tempClient))
+}
+
+func countWorkflowsHandler(tempClient client.Client) func(args CountWorkflowsParams) (*mcp.ToolResponse, error) {
+	return func(args CountWorkflowsParams) (*mcp.ToolResponse, error) {
+		// Check if Temporal client is available
+		if tempClient == nil {
+			log.Printf("Error: Temporal client is not available for counting workflows")
+			return degradedResponse("Error: Temporal client is not available for counting workflows")
+		}
+
+		resp, err := tempClient.CountWorkflow(context.Background(), &workflowservice.CountWorkflowExecutionsRequest{Query: args.Query})
+		if err != nil {
+			msg := fmt.Sprintf("Error: Failed to count workflows: %v", err)
+			if isVisibilityUnsupported(err) {
+				msg = fmt.Sprintf("Error: Counting workflows requires advanced visibility, which this Temporal server doesn't "+
+					"appear to have enabled: %v", err)
+			}
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		result := countWorkflowsResult{Count: resp.GetCount()}
+		dataConverter := converter.GetDefaultDataConverter()
+		for _, group := range resp.GetGroups() {
+			values := make([]interface{}, 0, len(group.GetGroupValues()))
+			for _, payload := range group.GetGroupValues() {
+				var value interface{}
+				if err := dataConverter.FromPayload(payload, &value); err != nil {
+					return nil, fmt.Errorf("failed to decode group value: %w", err)
+				}
+				values = append(values, value)
+			}
+			result.Groups = append(result.Groups, countWorkflowsGroup{Values: values, Count: group.GetCount()})
+		}
+
+		bytes, err := json.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResponse(mcp.NewTextContent(string(bytes))), nil
+	}
+}
+
+// isVisibilityUnsupported reports whether err means the server's visibility store can't run count queries
+func isVisibilityUnsupported(err error) bool {
+	var unimplemented *serviceerror.Unimplemented
+	if errors.As(err, &unimplemented) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "advanced visibility") || strings.Contains(msg, "not supported")
+}
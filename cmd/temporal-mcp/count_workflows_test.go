@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/converter"
+)
+
+func TestCountWorkflows(t *testing.T) {
+	t.Run("total", func(t *testing.T) {
+		mockClient := &mockTemporalClient{countResponse: &workflowservice.CountWorkflowExecutionsResponse{Count: 42}}
+		resp, err := countWorkflowsHandler(mockClient)(CountWorkflowsParams{Query: "WorkflowType = 'OrderWorkflow'"})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"count": 42}`, resp.Content[0].TextContent.Text)
+		require.Equal(t, "WorkflowType = 'OrderWorkflow'", mockClient.lastCountRequest.Query)
+	})
+
+	t.Run("grouped", func(t *testing.T) {
+		running, err := converter.GetDefaultDataConverter().ToPayload("Running")
+		require.NoError(t, err)
+		completed, err := converter.GetDefaultDataConverter().ToPayload("Completed")
+		require.NoError(t, err)
+
+		mockClient := &mockTemporalClient{countResponse: &workflowservice.CountWorkflowExecutionsResponse{
+			Count: 10,
+			Groups: []*workflowservice.CountWorkflowExecutionsResponse_AggregationGroup{
+				{GroupValues: []*common.Payload{running}, Count: 3},
+				{GroupValues: []*common.Payload{completed}, Count: 7},
+			},
+		}}
+		resp, err := countWorkflowsHandler(mockClient)(CountWorkflowsParams{Query: "GROUP BY ExecutionStatus"})
+		require.NoError(t, err)
+
+		var result countWorkflowsResult
+		require.NoError(t, json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &result))
+		require.Equal(t, int64(10), result.Count)
+		require.Equal(t, []countWorkflowsGroup{
+			{Values: []interface{}{"Running"}, Count: 3},
+			{Values: []interface{}{"Completed"}, Count: 7},
+		}, result.Groups)
+	})
+
+	t.Run("advanced visibility not enabled", func(t *testing.T) {
+		mockClient := &mockTemporalClient{countErr: serviceerror.NewUnimplemented("CountWorkflowExecutions is not implemented")}
+		resp, err := asResponse(countWorkflowsHandler(mockClient)(CountWorkflowsParams{}))
+		require.NoError(t, err)
+		require.Contains(t, resp.Content[0].TextContent.Text, "requires advanced visibility")
+	})
+}
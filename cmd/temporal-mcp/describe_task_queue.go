@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"go.temporal.io/sdk/client"
+)
+
+// DescribeTaskQueueParams are the arguments of the DescribeTaskQueue tool
+type DescribeTaskQueueParams struct {
+	TaskQueue string `json:"taskQueue"`
+	Type      string `json:"type,omitempty"`
+}
+
+// taskQueueTypes maps the DescribeTaskQueue tool's type argument to the SDK's task queue types
+var taskQueueTypes = map[string]client.TaskQueueType{
+	"workflow": client.TaskQueueTypeWorkflow,
+	"activity": client.TaskQueueTypeActivity,
+	"nexus":    client.TaskQueueTypeNexus,
+}
+
+// describeTaskQueueResult is the JSON returned by the DescribeTaskQueue tool
+type describeTaskQueueResult struct {
+	TaskQueue string                           `json:"taskQueue"`
+	Types     map[string]*taskQueueTypeSummary `json:"types"`
+}
+
+// taskQueueTypeSummary holds the pollers and backlog of one type of task queue (workflow, activity, nexus)
+type taskQueueTypeSummary struct {
+	Pollers []taskQueuePollerSummary `json:"pollers"`
+	Stats   *taskQueueStatsSummary   `json:"stats,omitempty"`
+}
+
+type taskQueuePollerSummary struct {
+	Identity       string     `json:"identity"`
+	LastAccessTime *time.Time `json:"lastAccessTime,omitempty"`
+	RatePerSecond  float64    `json:"ratePerSecond,omitempty"`
+	BuildID        string     `json:"buildId,omitempty"`
+}
+
+type taskQueueStatsSummary struct {
+	ApproximateBacklogCount      int64   `json:"approximateBacklogCount"`
+	ApproximateBacklogAgeSeconds float64 `json:"approximateBacklogAgeSeconds"`
+	TasksAddRate                 float32 `json:"tasksAddRate"`
+	TasksDispatchRate            float32 `json:"tasksDispatchRate"`
+	BacklogIncreaseRate          float32 `json:"backlogIncreaseRate"`
+}
+
+// registerDescribeTaskQueueTool registers a tool that reports a task queue's pollers and backlog
+func registerDescribeTaskQueueTool(server *mcp.Server, tempClient client.Client, cfg *config.Config) error {
+	desc := "Describes a task queue: the workers currently polling it (identity, last access time, poll rate) and its backlog " +
+		"(approximate task count and age, add/dispatch rates). type is optional - one of \"workflow\", \"activity\" or \"nexus\"; " +
+		"if omitted, workflow and activity tasks are described. Use this to diagnose why a workflow isn't making progress"
+
+	return registerTool(server, cfg, "DescribeTaskQueue", desc, describeTaskQueueHandler(tempClient))
+}
+
+func describeTaskQueueHandler(tempClient client.Client) func(args DescribeTaskQueueParams) (*mcp.ToolResponse, error) {
+	return func(args DescribeTaskQueueParams) (*mcp.ToolResponse, error) {
+		// Check if Temporal client is available
+		if tempClient == nil {
+			log.Printf("Error: Temporal client is not available for describing task queues")
+			return degradedResponse("Error: Temporal client is not available for describing task queues")
+		}
+		if args.TaskQueue == "" {
+			return errorResponse("Error: taskQueue is required")
+		}
+
+		typeNames := []string{"workflow", "activity"}
+		if args.Type != "" {
+			typeNames = []string{strings.ToLower(args.Type)}
+		}
+		var types []client.TaskQueueType
+		for _, name := range typeNames {
+			taskQueueType, ok := taskQueueTypes[name]
+			if !ok {
+				return errorResponse(fmt.Sprintf(
+					"Error: unknown task queue type %q - use one of \"workflow\", \"activity\" or \"nexus\"", args.Type,
+				))
+			}
+			types = append(types, taskQueueType)
+		}
+
+		description, err := tempClient.DescribeTaskQueueEnhanced(context.Background(), client.DescribeTaskQueueEnhancedOptions{
+			TaskQueue:      args.TaskQueue,
+			TaskQueueTypes: types,
+			ReportPollers:  true,
+			ReportStats:    true,
+		})
+		if err != nil {
+			msg := fmt.Sprintf("Error: Failed to describe task queue %s: %v", args.TaskQueue, err)
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		bytes, err := json.Marshal(newDescribeTaskQueueResult(args.TaskQueue, typeNames, description))
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResponse(mcp.NewTextContent(string(bytes))), nil
+	}
+}
+
+// newDescribeTaskQueueResult summarizes the description of each requested type. Without a versioning selector the
+// server reports a single build ID (the default, or "" when unversioned), but should it report several, their pollers
+// are merged and their backlogs added up.
+func newDescribeTaskQueueResult(taskQueue string, typeNames []string, description client.TaskQueueDescription) describeTaskQueueResult {
+	result := describeTaskQueueResult{TaskQueue: taskQueue, Types: map[string]*taskQueueTypeSummary{}}
+	for _, name := range typeNames {
+		result.Types[name] = &taskQueueTypeSummary{Pollers: []taskQueuePollerSummary{}}
+	}
+
+	for _, buildID := range slices.Sorted(maps.Keys(description.VersionsInfo)) {
+		for _, name := range typeNames {
+			info, ok := description.VersionsInfo[buildID].TypesInfo[taskQueueTypes[name]]
+			if !ok {
+				continue
+			}
+			summary := result.Types[name]
+			for _, poller := range info.Pollers {
+				pollerSummary := taskQueuePollerSummary{Identity: poller.Identity, RatePerSecond: poller.RatePerSecond, BuildID: buildID}
+				if !poller.LastAccessTime.IsZero() {
+					lastAccessTime := poller.LastAccessTime
+					pollerSummary.LastAccessTime = &lastAccessTime
+				}
+				summary.Pollers = append(summary.Pollers, pollerSummary)
+			}
+			if info.Stats != nil {
+				summary.Stats = addTaskQueueStats(summary.Stats, info.Stats)
+			}
+		}
+	}
+	return result
+}
+
+// addTaskQueueStats adds stats to the running total, keeping the oldest backlog age
+func addTaskQueueStats(total *taskQueueStatsSummary, stats *client.TaskQueueStats) *taskQueueStatsSummary {
+	if total == nil {
+		total = &taskQueueStatsSummary{}
+	}
+	total.ApproximateBacklogCount += stats.ApproximateBacklogCount
+	total.ApproximateBacklogAgeSeconds = max(total.ApproximateBacklogAgeSeconds, stats.ApproximateBacklogAge.Seconds())
+	total.TasksAddRate += stats.TasksAddRate
+	total.TasksDispatchRate += stats.TasksDispatchRate
+	total.BacklogIncreaseRate += stats.BacklogIncreaseRate
+	return total
+}
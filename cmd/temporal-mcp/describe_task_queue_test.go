@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/client"
+)
+
+func TestDescribeTaskQueue(t *testing.T) {
+	lastPoll := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockClient := &mockTemporalClient{taskQueueDescription: client.TaskQueueDescription{
+		VersionsInfo: map[string]client.TaskQueueVersionInfo{
+			"": {TypesInfo: map[client.TaskQueueType]client.TaskQueueTypeInfo{
+				client.TaskQueueTypeWorkflow: {
+					Pollers: []client.TaskQueuePollerInfo{{Identity: "worker-1@host", LastAccessTime: lastPoll, RatePerSecond: 100}},
+					Stats:   &client.TaskQueueStats{ApproximateBacklogCount: 12, ApproximateBacklogAge: 90 * time.Second, TasksAddRate: 2},
+				},
+				client.TaskQueueTypeActivity: {},
+			}},
+		},
+	}}
+
+	resp, err := describeTaskQueueHandler(mockClient)(DescribeTaskQueueParams{TaskQueue: "orders"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"taskQueue": "orders",
+		"types": {
+			"workflow": {
+				"pollers": [{"identity": "worker-1@host", "lastAccessTime": "2025-05-01T12:00:00Z", "ratePerSecond": 100}],
+				"stats": {
+					"approximateBacklogCount": 12,
+					"approximateBacklogAgeSeconds": 90,
+					"tasksAddRate": 2,
+					"tasksDispatchRate": 0,
+					"backlogIncreaseRate": 0
+				}
+			},
+			"activity": {"pollers": []}
+		}
+	}`, resp.Content[0].TextContent.Text)
+
+	opts := mockClient.lastDescribeTaskQueueOpts
+	require.Equal(t, "orders", opts.TaskQueue)
+	require.Equal(t, []client.TaskQueueType{client.TaskQueueTypeWorkflow, client.TaskQueueTypeActivity}, opts.TaskQueueTypes)
+	require.True(t, opts.ReportPollers)
+	require.True(t, opts.ReportStats)
+
+	t.Run("single type", func(t *testing.T) {
+		resp, err := describeTaskQueueHandler(mockClient)(DescribeTaskQueueParams{TaskQueue: "orders", Type: "Activity"})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"taskQueue": "orders", "types": {"activity": {"pollers": []}}}`, resp.Content[0].TextContent.Text)
+		require.Equal(t, []client.TaskQueueType{client.TaskQueueTypeActivity}, mockClient.lastDescribeTaskQueueOpts.TaskQueueTypes)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		resp, err := asResponse(describeTaskQueueHandler(mockClient)(DescribeTaskQueueParams{TaskQueue: "orders", Type: "sticky"}))
+		require.NoError(t, err)
+		require.Contains(t, resp.Content[0].TextContent.Text, `Error: unknown task queue type "sticky"`)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		failing := &mockTemporalClient{taskQueueDescriptionErr: errors.New("permission denied")}
+		resp, err := asResponse(describeTaskQueueHandler(failing)(DescribeTaskQueueParams{TaskQueue: "orders"}))
+		require.NoError(t, err)
+		require.Equal(t, "Error: Failed to describe task queue orders: permission denied", resp.Content[0].TextContent.Text)
+	})
+}
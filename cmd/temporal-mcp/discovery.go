@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"go.temporal.io/sdk/client"
+)
+
+// discoveryTimeout bounds workflow discovery at startup
+const discoveryTimeout = 30 * time.Second
+
+// discoveredPurposePrefix marks the descriptions of tools registered by discovery rather than config
+const discoveredPurposePrefix = "[Auto-discovered] "
+
+// discoveredWorkflow is a workflow type found on the Temporal server
+type discoveredWorkflow struct {
+	Name      string
+	TaskQueue string
+	Source    string // where it was found, for the tool description
+}
+
+// workflowDiscoverySource lists workflow types known to the Temporal server
+type workflowDiscoverySource interface {
+	DiscoverWorkflows(ctx context.Context) ([]discoveredWorkflow, error)
+}
+
+// scheduleDiscoverySource discovers the workflow types started by existing schedules. The server has no API listing
+// the workflow types workers have registered, so schedules are the best available record.
+type scheduleDiscoverySource struct {
+	client client.Client
+}
+
+// DiscoverWorkflows lists schedules and describes each to find the workflow type and task queue it starts
+func (s scheduleDiscoverySource) DiscoverWorkflows(ctx context.Context) ([]discoveredWorkflow, error) {
+	iterator, err := s.client.ScheduleClient().List(ctx, client.ScheduleListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedules: %w", err)
+	}
+
+	var workflows []discoveredWorkflow
+	for iterator.HasNext() {
+		entry, err := iterator.Next()
+		if err != nil {
+			return workflows, fmt.Errorf("failed to list schedules: %w", err)
+		}
+
+		description, err := s.client.ScheduleClient().GetHandle(ctx, entry.ID).Describe(ctx)
+		if err != nil {
+			log.Printf("WARNING: Skipping schedule %s during workflow discovery: %v", entry.ID, err)
+			continue
+		}
+		action, ok := description.Schedule.Action.(*client.ScheduleWorkflowAction)
+		if !ok {
+			continue
+		}
+		workflows = append(workflows, discoveredWorkflow{
+			Name:      fmt.Sprint(action.Workflow),
+			TaskQueue: action.TaskQueue,
+			Source:    "schedule " + entry.ID,
+		})
+	}
+	return workflows, nil
+}
+
+// registerDiscoveredWorkflowTools registers a basic tool, taking free-form params, for every discovered workflow that
+// isn't already defined in config, as far as maxWorkflows allows. Config-defined workflows always take precedence.
+// Registered workflows are added to cfg.Workflows, so the system prompt lists them along with the configured ones; a
+// workflow without a task queue is skipped. Returns the names registered.
+func registerDiscoveredWorkflowTools(ctx context.Context, server *mcp.Server, cfg *config.Config, tempClient client.Client, source workflowDiscoverySource) ([]string, error) {
+	discovered, err := source.DiscoverWorkflows(ctx)
+	if err != nil && len(discovered) == 0 {
+		return nil, err
+	}
+	if err != nil {
+		log.Printf("WARNING: Workflow discovery was incomplete: %v", err)
+	}
+
+	byName := map[string]discoveredWorkflow{}
+	for _, workflow := range discovered {
+		if _, configured := cfg.Workflows[workflow.Name]; configured || workflow.Name == "" {
+			continue
+		}
+		if _, seen := byName[workflow.Name]; !seen {
+			byName[workflow.Name] = workflow
+		}
+	}
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Discovered tools count against maxWorkflows along with the configured ones, which always take precedence
+	remaining := max(maxWorkflowTools(cfg)-len(cfg.Workflows), 0)
+	if len(names) > remaining {
+		log.Printf("WARNING: %d workflows were discovered but only %d more fit within maxWorkflows (%d) - registering "+
+			"only the first %d (by name)", len(names), remaining, maxWorkflowTools(cfg), remaining)
+		names = names[:remaining]
+	}
+
+	registered := make([]string, 0, len(names))
+	for _, name := range names {
+		workflow := byName[name]
+		def := config.WorkflowDef{
+			Purpose: fmt.Sprintf("%sStarts the %s workflow (found via %s). It isn't described in the server config, so its "+
+				"parameters are unknown - pass whatever the workflow expects in params.", discoveredPurposePrefix, name, workflow.Source),
+			TaskQueue:  workflow.TaskQueue,
+			Discovered: true,
+		}
+		err := registerWorkflowTool(server, name, def, tempClient, cfg)
+		if errors.Is(err, errNoTaskQueue) {
+			log.Printf("WARNING: Skipping discovered workflow %s: %v", name, err)
+			continue
+		}
+		if err != nil {
+			return registered, fmt.Errorf("failed to register discovered workflow tool %s: %w", name, err)
+		}
+		if cfg.Workflows == nil {
+			cfg.Workflows = map[string]config.WorkflowDef{}
+		}
+		cfg.Workflows[name] = def
+		registered = append(registered, name)
+		log.Printf("Registered auto-discovered workflow tool: %s", name)
+	}
+	return registered, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	mcphttp "github.com/metoro-io/mcp-golang/transport/http"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDiscoverySource returns a fixed list of workflows
+type fakeDiscoverySource []discoveredWorkflow
+
+func (f fakeDiscoverySource) DiscoverWorkflows(ctx context.Context) ([]discoveredWorkflow, error) {
+	return f, nil
+}
+
+func TestRegisterDiscoveredWorkflowTools(t *testing.T) {
+	server := mcp.NewServer(mcphttp.NewHTTPTransport("/mcp"))
+	cfg := &config.Config{Workflows: map[string]config.WorkflowDef{"OrderWorkflow": testWorkflow()}}
+	source := fakeDiscoverySource{
+		{Name: "OrderWorkflow", TaskQueue: "other", Source: "schedule orders"},
+		{Name: "ReportWorkflow", TaskQueue: "reports", Source: "schedule nightly"},
+		{Name: "ReportWorkflow", TaskQueue: "reports", Source: "schedule weekly"},
+	}
+
+	names, err := registerDiscoveredWorkflowTools(context.Background(), server, cfg, nil, source)
+	require.NoError(t, err)
+	require.Equal(t, []string{"ReportWorkflow"}, names)
+	require.True(t, server.CheckToolRegistered("ReportWorkflow"))
+}
+
+func TestDiscoveredWorkflowToolRuns(t *testing.T) {
+	mockClient := &mockTemporalClient{runResult: "ok"}
+	def := config.WorkflowDef{Purpose: discoveredPurposePrefix + "Starts ReportWorkflow", TaskQueue: "reports", Discovered: true}
+
+	resp, err := workflowToolHandler("ReportWorkflow", def, mockClient, nil)(WorkflowParams{Params: map[string]string{"day": "monday"}})
+	require.NoError(t, err)
+	require.Equal(t, "ok", resp.Content[0].TextContent.Text)
+	require.Equal(t, "reports", mockClient.lastStartOptions.TaskQueue)
+	require.Equal(t, "ReportWorkflow", mockClient.lastWorkflowName)
+
+	t.Run("strictParams doesn't apply", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runResult: "ok"}
+		cfg := &config.Config{StrictParams: true}
+		resp, err := workflowToolHandler("ReportWorkflow", def, mockClient, cfg)(WorkflowParams{Params: map[string]string{"day": "monday"}})
+		require.NoError(t, err)
+		require.Equal(t, "ok", resp.Content[0].TextContent.Text)
+	})
+}
+
+func TestDiscoveredWorkflowToolsCountAgainstMaxWorkflows(t *testing.T) {
+	server := mcp.NewServer(mcphttp.NewHTTPTransport("/mcp"))
+	cfg := &config.Config{
+		MaxWorkflows: 2,
+		Workflows:    map[string]config.WorkflowDef{"OrderWorkflow": testWorkflow()},
+	}
+	source := fakeDiscoverySource{
+		{Name: "ReportWorkflow", TaskQueue: "reports", Source: "schedule nightly"},
+		{Name: "AuditWorkflow", TaskQueue: "audits", Source: "schedule daily"},
+	}
+
+	names, err := registerDiscoveredWorkflowTools(context.Background(), server, cfg, nil, source)
+	require.NoError(t, err)
+	require.Equal(t, []string{"AuditWorkflow"}, names)
+	require.False(t, server.CheckToolRegistered("ReportWorkflow"))
+
+	// Configured workflows already at the limit leave no room
+	cfg.MaxWorkflows = 1
+	names, err = registerDiscoveredWorkflowTools(context.Background(), mcp.NewServer(mcphttp.NewHTTPTransport("/mcp")), cfg, nil, source)
+	require.NoError(t, err)
+	require.Empty(t, names)
+}
+
+func TestDiscoveredWorkflowsListedAndSkippedWithoutTaskQueue(t *testing.T) {
+	server := mcp.NewServer(mcphttp.NewHTTPTransport("/mcp"))
+	cfg := &config.Config{Workflows: map[string]config.WorkflowDef{"OrderWorkflow": testWorkflow()}}
+	source := fakeDiscoverySource{
+		{Name: "AuditWorkflow", Source: "schedule daily"}, // No task queue, and no default to fall back on
+		{Name: "ReportWorkflow", TaskQueue: "reports", Source: "schedule nightly"},
+	}
+
+	names, err := registerDiscoveredWorkflowTools(context.Background(), server, cfg, nil, source)
+	require.NoError(t, err)
+	require.Equal(t, []string{"ReportWorkflow"}, names)
+	require.False(t, server.CheckToolRegistered("AuditWorkflow"))
+
+	// Discovered tools are described alongside the configured ones
+	prompt := buildSystemPrompt(cfg)
+	require.Contains(t, prompt, "## OrderWorkflow")
+	require.Contains(t, prompt, "## ReportWorkflow")
+	require.NotContains(t, prompt, "AuditWorkflow")
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+)
+
+// buildExampleParams renders the body of the "params" object used in example usage blocks, one field per line. A
+// workflow's curated exampleParams are used as they are; otherwise examples are generated from the input fields.
+func buildExampleParams(workflow config.WorkflowDef) string {
+	paramExamples := []string{}
+	if len(workflow.ExampleParams) > 0 {
+		for _, fieldName := range slices.Sorted(maps.Keys(workflow.ExampleParams)) {
+			value, err := json.Marshal(workflow.ExampleParams[fieldName])
+			if err != nil {
+				value = []byte(strconv.Quote(fmt.Sprint(workflow.ExampleParams[fieldName])))
+			}
+			// Params can't be objects or arrays, so those are shown the way they must be passed: JSON-encoded
+			if value[0] == '{' || value[0] == '[' {
+				value = []byte(strconv.Quote(string(value)))
+			}
+			paramExamples = append(paramExamples, fmt.Sprintf("    \"%s\": %s", fieldName, value))
+		}
+		return strings.Join(paramExamples, ",\n")
+	}
+
+	for _, field := range workflow.Input.Fields {
+		for _, fieldName := range slices.Sorted(maps.Keys(field)) {
+			value := exampleParamValue(fieldName, workflow.Input.FieldTypes[fieldName])
+			paramExamples = append(paramExamples, fmt.Sprintf("    \"%s\": %s", fieldName, value))
+		}
+	}
+	return strings.Join(paramExamples, ",\n")
+}
+
+// buildExampleUsage renders a complete JSON example of a call to the given workflow's tool (without code fences)
+func buildExampleUsage(workflow config.WorkflowDef) string {
+	return "{\n  \"params\": {\n" + buildExampleParams(workflow) + "\n  },\n  \"force_rerun\": false\n}"
+}
+
+// exampleObjectParam is the example value of object params, JSON-encoded as they must be passed
+const exampleObjectParam = `"{\"example\": \"value\"}"`
+
+// exampleParamValue returns a JSON literal suitable as an example value for a field. Declared field types take
+// precedence; fields without a declared type fall back to guessing from the field name. Params are strings, numbers or
+// booleans, so object and array examples are JSON-encoded strings.
+func exampleParamValue(fieldName string, fieldType string) string {
+	switch strings.ToLower(fieldType) {
+	case "string":
+		if strings.Contains(fieldName, "id") {
+			return "\"example-id-123\""
+		}
+		return "\"example value\""
+	case "number":
+		return "1.5"
+	case "integer", "int":
+		return "42"
+	case "boolean", "bool":
+		return "true"
+	case "object":
+		return exampleObjectParam
+	case "array":
+		return `"[\"example value\"]"`
+	}
+
+	// No (or unknown) declared type - guess from the field name
+	if strings.Contains(fieldName, "json") {
+		return exampleObjectParam
+	} else if strings.Contains(fieldName, "id") {
+		return "\"example-id-123\""
+	}
+	return "\"example value\""
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"testing"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	mcphttp "github.com/metoro-io/mcp-golang/transport/http"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+)
+
+func TestExampleParamValue(t *testing.T) {
+	type Case struct {
+		fieldName string
+		fieldType string
+		expected  string
+	}
+
+	tests := map[string]Case{
+		"boolean field":          {fieldName: "dry_run", fieldType: "boolean", expected: "true"},
+		"integer field":          {fieldName: "count", fieldType: "integer", expected: "42"},
+		"number field":           {fieldName: "amount", fieldType: "number", expected: "1.5"},
+		"object field":           {fieldName: "options", fieldType: "object", expected: `"{\"example\": \"value\"}"`},
+		"array field":            {fieldName: "tags", fieldType: "array", expected: `"[\"example value\"]"`},
+		"string id field":        {fieldName: "order_id", fieldType: "string", expected: `"example-id-123"`},
+		"declared type wins":     {fieldName: "payload_json", fieldType: "string", expected: `"example value"`},
+		"untyped json heuristic": {fieldName: "payload_json", expected: `"{\"example\": \"value\"}"`},
+		"untyped id heuristic":   {fieldName: "order_id", expected: `"example-id-123"`},
+		"untyped fallback":       {fieldName: "name", expected: `"example value"`},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, exampleParamValue(tc.fieldName, tc.fieldType))
+		})
+	}
+}
+
+func TestExampleParamsAreAccepted(t *testing.T) {
+	fieldTypes := map[string]string{
+		"dry_run": "boolean", "count": "integer", "amount": "number", "options": "object", "tags": "array",
+		"order_id": "string", "payload_json": "", "name": "",
+	}
+	workflow := config.WorkflowDef{Input: config.ParameterDef{FieldTypes: fieldTypes}}
+	for fieldName := range fieldTypes {
+		workflow.Input.Fields = append(workflow.Input.Fields, map[string]string{fieldName: "A field"})
+	}
+	curated := config.WorkflowDef{ExampleParams: map[string]any{
+		"order_id": "ORD-1", "express": true, "items": []any{"SKU-1"}, "address": map[string]any{"zip": "94105"},
+	}}
+
+	for name, tc := range map[string]struct {
+		workflow config.WorkflowDef
+		count    int
+	}{
+		"generated": {workflow: workflow, count: len(fieldTypes)},
+		"curated":   {workflow: curated, count: len(curated.ExampleParams)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var params ParamsMap
+			require.NoError(t, params.UnmarshalJSON([]byte("{\n"+buildExampleParams(tc.workflow)+"\n}")))
+			require.Len(t, params, tc.count)
+		})
+	}
+}
+
+func TestBuildExampleUsageWithBooleanField(t *testing.T) {
+	workflow := config.WorkflowDef{
+		Input: config.ParameterDef{
+			Fields: []map[string]string{
+				{"notify": "Whether to send a notification"},
+			},
+			FieldTypes: map[string]string{"notify": "boolean"},
+		},
+	}
+
+	example := buildExampleUsage(workflow)
+	require.Contains(t, example, `"notify": true`)
+	require.NotContains(t, example, `"notify": "example value"`)
+}
+
+func TestCustomExampleParams(t *testing.T) {
+	workflow := config.WorkflowDef{
+		Purpose:   "Ships an order",
+		TaskQueue: "orders",
+		Input: config.ParameterDef{
+			Fields: []map[string]string{{"order_id": "The order"}, {"items": "JSON array of SKUs"}},
+		},
+		ExampleParams: map[string]any{"order_id": "ORD-2025-0042", "items": `["SKU-1","SKU-2"]`, "express": true},
+	}
+	expected := "    \"express\": true,\n    \"items\": \"[\\\"SKU-1\\\",\\\"SKU-2\\\"]\",\n    \"order_id\": \"ORD-2025-0042\""
+	require.Equal(t, expected, buildExampleParams(workflow))
+
+	cfg := &config.Config{Workflows: map[string]config.WorkflowDef{"ShipOrder": workflow}}
+	server := mcp.NewServer(mcphttp.NewHTTPTransport("/mcp"))
+	require.NoError(t, registerWorkflowTools(server, cfg, nil))
+	tools := registeredTools(server)
+	require.Len(t, tools, 1)
+	require.Contains(t, tools[0].Description, expected)
+	require.NotContains(t, tools[0].Description, "example-id-123")
+
+	prompt := buildSystemPrompt(cfg)
+	require.Contains(t, prompt, expected)
+	require.NotContains(t, prompt, "example-id-123")
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// resultFormatter renders a decoded workflow result as tool response text
+type resultFormatter func(result interface{}) (string, error)
+
+// resultFormatters maps a workflow's outputFormat to its formatter. The empty format is the default rendering.
+var resultFormatters = map[string]resultFormatter{
+	"":            workflowResultText,
+	"raw":         workflowResultText,
+	"json-pretty": formatJSONPretty,
+	"csv-table":   formatCSVTable,
+	"binary":      formatBinary,
+}
+
+// formatWorkflowResult renders result with the formatter registered for format
+func formatWorkflowResult(format string, result interface{}) (string, error) {
+	formatter, ok := resultFormatters[format]
+	if !ok {
+		return "", fmt.Errorf("unknown outputFormat %q", format)
+	}
+	return formatter(result)
+}
+
+// extractResultField returns the value at path, a dot-separated list of object keys and array indexes (e.g.
+// "shipment.items.0.sku"), in a decoded workflow result
+func extractResultField(result interface{}, path string) (interface{}, error) {
+	segments := strings.Split(path, ".")
+	value := result
+	for i, segment := range segments {
+		switch node := value.(type) {
+		case map[string]interface{}:
+			field, ok := node[segment]
+			if !ok {
+				return nil, fmt.Errorf("result has no field %q", strings.Join(segments[:i+1], "."))
+			}
+			value = field
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, fmt.Errorf("result has no element %q", strings.Join(segments[:i+1], "."))
+			}
+			value = node[index]
+		default:
+			return nil, fmt.Errorf("result has no field %q: %q is not an object or array",
+				path, strings.Join(segments[:i], "."))
+		}
+	}
+	return value, nil
+}
+
+// formatJSONPretty renders result as indented JSON. A string that holds JSON is re-indented; any other string is
+// returned as-is.
+func formatJSONPretty(result interface{}) (string, error) {
+	if str, ok := result.(string); ok {
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(str), &decoded); err != nil {
+			return str, nil
+		}
+		result = decoded
+	}
+	bytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// formatBinary renders a []byte result (a PDF, an image, ...) as base64 after a note saying so. Workers' []byte results
+// arrive as binary payloads, which decode to []byte; any other result gets the default rendering.
+func formatBinary(result interface{}) (string, error) {
+	data, ok := result.([]byte)
+	if !ok {
+		return workflowResultText(result)
+	}
+	return fmt.Sprintf("Binary result (%d bytes), base64-encoded:\n%s", len(data), base64.StdEncoding.EncodeToString(data)), nil
+}
+
+// formatCSVTable renders result as a markdown table. result is either an array of objects, whose keys become the
+// (sorted) columns, or a CSV string whose first record is the header.
+func formatCSVTable(result interface{}) (string, error) {
+	var header []string
+	var rows [][]string
+
+	switch value := result.(type) {
+	case string:
+		records, err := csv.NewReader(strings.NewReader(value)).ReadAll()
+		if err != nil {
+			return "", fmt.Errorf("csv-table: failed to parse CSV result: %w", err)
+		}
+		if len(records) == 0 {
+			return "", nil
+		}
+		header, rows = records[0], records[1:]
+	case []interface{}:
+		columns := map[string]bool{}
+		for i, item := range value {
+			object, ok := item.(map[string]interface{})
+			if !ok {
+				return "", fmt.Errorf("csv-table: element %d of the result is %T, not an object", i, item)
+			}
+			for key := range object {
+				columns[key] = true
+			}
+		}
+		for key := range columns {
+			header = append(header, key)
+		}
+		sort.Strings(header)
+		for _, item := range value {
+			object := item.(map[string]interface{})
+			row := make([]string, len(header))
+			for i, key := range header {
+				cell, err := tableCellText(object[key])
+				if err != nil {
+					return "", err
+				}
+				row[i] = cell
+			}
+			rows = append(rows, row)
+		}
+	default:
+		return "", fmt.Errorf("csv-table: result is %T, not an array of objects or a CSV string", result)
+	}
+
+	var b strings.Builder
+	writeTableRow(&b, header)
+	separator := make([]string, len(header))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	writeTableRow(&b, separator)
+	for _, row := range rows {
+		writeTableRow(&b, row)
+	}
+	return b.String(), nil
+}
+
+// tableCellText renders one value of an object for a markdown table cell
+func tableCellText(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	}
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// writeTableRow writes one markdown table row, escaping characters that would break the table
+func writeTableRow(b *strings.Builder, cells []string) {
+	b.WriteString("|")
+	for _, cell := range cells {
+		cell = strings.ReplaceAll(cell, "|", `\|`)
+		cell = strings.ReplaceAll(cell, "\r\n", " ")
+		cell = strings.ReplaceAll(cell, "\n", " ")
+		b.WriteString(" ")
+		b.WriteString(cell)
+		b.WriteString(" |")
+	}
+	b.WriteString("\n")
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"testing"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	mcphttp "github.com/metoro-io/mcp-golang/transport/http"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+)
+
+func TestFormatJSONPretty(t *testing.T) {
+	t.Run("object", func(t *testing.T) {
+		actual, err := formatWorkflowResult("json-pretty", map[string]interface{}{"b": 1.0, "a": []interface{}{"x"}})
+		require.NoError(t, err)
+		require.Equal(t, "{\n  \"a\": [\n    \"x\"\n  ],\n  \"b\": 1\n}", actual)
+	})
+
+	t.Run("JSON string is re-indented", func(t *testing.T) {
+		actual, err := formatWorkflowResult("json-pretty", `{"ok":true}`)
+		require.NoError(t, err)
+		require.Equal(t, "{\n  \"ok\": true\n}", actual)
+	})
+
+	t.Run("plain string is returned as-is", func(t *testing.T) {
+		actual, err := formatWorkflowResult("json-pretty", "done")
+		require.NoError(t, err)
+		require.Equal(t, "done", actual)
+	})
+}
+
+func TestFormatCSVTable(t *testing.T) {
+	t.Run("array of objects", func(t *testing.T) {
+		result := []interface{}{
+			map[string]interface{}{"name": "alpha", "count": 1.0},
+			map[string]interface{}{"name": "a|b", "extra": nil},
+		}
+		actual, err := formatWorkflowResult("csv-table", result)
+		require.NoError(t, err)
+		require.Equal(t, ""+
+			"| count | extra | name |\n"+
+			"| --- | --- | --- |\n"+
+			"| 1 |  | alpha |\n"+
+			"|  |  | a\\|b |\n", actual)
+	})
+
+	t.Run("CSV string", func(t *testing.T) {
+		actual, err := formatWorkflowResult("csv-table", "id,status\n1,done\n2,\"multi\nline\"\n")
+		require.NoError(t, err)
+		require.Equal(t, ""+
+			"| id | status |\n"+
+			"| --- | --- |\n"+
+			"| 1 | done |\n"+
+			"| 2 | multi line |\n", actual)
+	})
+
+	t.Run("not tabular", func(t *testing.T) {
+		_, err := formatWorkflowResult("csv-table", []interface{}{"x"})
+		require.Error(t, err)
+		_, err = formatWorkflowResult("csv-table", 42.0)
+		require.Error(t, err)
+	})
+}
+
+func TestFormatUnknown(t *testing.T) {
+	_, err := formatWorkflowResult("yaml", "x")
+	require.Error(t, err)
+}
+
+func TestUnknownOutputFormatRejectedAtRegistration(t *testing.T) {
+	workflow := testWorkflow()
+	workflow.OutputFormat = "yaml"
+	server := mcp.NewServer(mcphttp.NewHTTPTransport("/mcp"))
+	err := registerWorkflowTool(server, "OrderWorkflow", workflow, nil, &config.Config{})
+	require.ErrorContains(t, err, `unknown outputFormat "yaml"`)
+}
+
+func TestWorkflowToolUnformattableResult(t *testing.T) {
+	workflow := testWorkflow()
+	workflow.OutputFormat = "csv-table"
+
+	mockClient := &mockTemporalClient{runResult: 42}
+	_, err := workflowToolHandler("OrderWorkflow", workflow, mockClient, nil)(WorkflowParams{Params: map[string]string{"order_id": "1"}})
+	var toolErr *toolError
+	require.ErrorAs(t, err, &toolErr)
+	require.Contains(t, toolErr.message, "completed, but its result could not be formatted: csv-table")
+}
+
+func TestWorkflowToolBinaryResult(t *testing.T) {
+	pdf := []byte{0x25, 0x50, 0x44, 0x46, 0x00, 0xff, 0xfe}
+	workflow := testWorkflow()
+	workflow.OutputFormat = "binary"
+
+	mockClient := &mockTemporalClient{runResult: pdf}
+	resp, err := workflowToolHandler("OrderWorkflow", workflow, mockClient, nil)(WorkflowParams{Params: map[string]string{"order_id": "1"}})
+	require.NoError(t, err)
+	require.Equal(t, "Binary result (7 bytes), base64-encoded:\nJVBERgD//g==", resp.Content[0].TextContent.Text)
+
+	// Non-binary results get the default rendering
+	actual, err := formatWorkflowResult("binary", "plain text")
+	require.NoError(t, err)
+	require.Equal(t, "plain text", actual)
+}
@@ -8,14 +8,21 @@ import (
 )
 
 // hashWorkflowArgs produces a short (suitable for inclusion in workflow id) hash of the given arguments. Args must be
-// json.Marshal-able.
-func hashWorkflowArgs(allParams map[string]string, paramsToHash ...any) (string, error) {
+// json.Marshal-able. A non-empty salt is hashed ahead of the arguments so that identical params produce different
+// hashes for different tenants/environments; an empty salt leaves hashes unchanged.
+//
+// allParams is hashed when no arguments are given. Its values may be of any JSON type; a map holding only strings
+// marshals exactly like the equivalent map[string]string, so IDs computed from string params are stable.
+func hashWorkflowArgs(salt string, allParams map[string]interface{}, paramsToHash ...any) (string, error) {
 	if len(paramsToHash) == 0 {
 		log.Printf("Warning: No hash arguments provided - will hash all arguments. Please replace {{ hash }} with {{ hash . }} in the workflowIDRecipe")
 		paramsToHash = []any{allParams}
 	}
 
 	hasher := fnv.New32()
+	if salt != "" {
+		_, _ = hasher.Write([]byte(salt))
+	}
 	for _, arg := range paramsToHash {
 		// important: json.Marshal sorts map keys
 		bytes, err := json.Marshal(arg)
@@ -26,3 +33,15 @@ func hashWorkflowArgs(allParams map[string]string, paramsToHash ...any) (string,
 	}
 	return fmt.Sprintf("%d", hasher.Sum32()), nil
 }
+
+// paramValues widens string params to the map hashWorkflowArgs hashes
+func paramValues(params map[string]string) map[string]interface{} {
+	if params == nil {
+		return nil
+	}
+	values := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		values[k] = v
+	}
+	return values
+}
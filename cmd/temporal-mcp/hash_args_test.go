@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashWorkflowArgsStringParamsStable(t *testing.T) {
+	// 2560999933 is the hash produced when all params were a map[string]string
+	params := map[string]string{"b": "2", "a": "1"}
+
+	actual, err := hashWorkflowArgs("", paramValues(params))
+	require.NoError(t, err)
+	require.Equal(t, "2560999933", actual)
+
+	explicit, err := hashWorkflowArgs("", nil, params)
+	require.NoError(t, err)
+	require.Equal(t, actual, explicit)
+}
+
+func TestHashWorkflowArgsTypedParams(t *testing.T) {
+	typed := func() map[string]interface{} {
+		return map[string]interface{}{
+			"amount": 12.5,
+			"tags":   []interface{}{"x", "y"},
+			"nested": map[string]interface{}{"z": true, "a": nil},
+		}
+	}
+
+	first, err := hashWorkflowArgs("", typed())
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		again, err := hashWorkflowArgs("", typed())
+		require.NoError(t, err)
+		require.Equal(t, first, again)
+	}
+
+	// A number and its string form are different params
+	asString, err := hashWorkflowArgs("", map[string]interface{}{"amount": "12.5"})
+	require.NoError(t, err)
+	asNumber, err := hashWorkflowArgs("", map[string]interface{}{"amount": 12.5})
+	require.NoError(t, err)
+	require.NotEqual(t, asString, asNumber)
+}
+
+func TestWorkflowIDHashesTypedInput(t *testing.T) {
+	workflow := config.WorkflowDef{WorkflowIDRecipe: "pay_{{ hash }}"}
+	params := map[string]string{"amount": "12.5"}
+
+	// Without an input schema the string params are hashed
+	plain, err := computeWorkflowID(workflow, params, nil, workflowIDOptions{})
+	require.NoError(t, err)
+	expected, err := hashWorkflowArgs("", map[string]interface{}{"amount": "12.5"})
+	require.NoError(t, err)
+	require.Equal(t, "pay_"+expected, plain)
+
+	// With one, the typed input the workflow receives is
+	typed, err := computeWorkflowID(workflow, params, map[string]interface{}{"amount": 12.5}, workflowIDOptions{})
+	require.NoError(t, err)
+	expected, err = hashWorkflowArgs("", map[string]interface{}{"amount": 12.5})
+	require.NoError(t, err)
+	require.Equal(t, "pay_"+expected, typed)
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"github.com/mocksi/temporal-mcp/internal/sanitize_history_event"
+	temporal_enums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/history/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// GetWorkflowHistoryParams are the arguments of the GetWorkflowHistory tool
+type GetWorkflowHistoryParams struct {
+	NamespaceParam
+
+	WorkflowID string `json:"workflowId"`
+	RunID      string `json:"runId"`
+	HeadEvents int    `json:"headEvents,omitempty"`
+	TailEvents int    `json:"tailEvents,omitempty"`
+
+	// protojson output options; the defaults give compact JSON with camelCase field names
+	EmitDefaults       bool `json:"emitDefaults,omitempty"`
+	UseProtoFieldNames bool `json:"useProtoFieldNames,omitempty"`
+	Indent             bool `json:"indent,omitempty"`
+
+	// DecodePayloads shows JSON payloads as JSON instead of base64; payloads are only included with historyIncludePayloads
+	DecodePayloads bool `json:"decodePayloads,omitempty"`
+
+	// Fields, when set, projects each event down to these top-level fields (e.g. eventId, eventType, eventTime)
+	Fields []string `json:"fields,omitempty"`
+}
+
+// registerGetWorkflowHistoryTool registres a tool that gets workflow histories
+func registerGetWorkflowHistoryTool(server *mcp.Server, tempClient client.Client, clients namespaceClients, cfg *config.Config) error {
+	desc := "Gets the workflow execution history for a specific run of a workflow. runId is optional - if omitted, this tool gets the history for the " + runSelector(cfg) + " run of the given workflowId. " +
+		"headEvents and tailEvents are optional - if either is set, only the first headEvents and last tailEvents events are returned, " +
+		"with a \"...omitted N events...\" marker in place of the rest. Use them for a quick look at how a long workflow started and ended. " +
+		"emitDefaults (include zero-valued fields), useProtoFieldNames (snake_case instead of camelCase field names) and indent " +
+		"(human-readable, multi-line output) are optional and change only how events are formatted. fields is optional - if set " +
+		"(e.g. [\"eventId\", \"eventType\", \"eventTime\"]), each event is cut down to just those fields, which makes an " +
+		"overview of a long history much smaller"
+	if cfg.HistoryIncludePayloads {
+		desc += ". decodePayloads is optional - if true, JSON payloads (inputs, results, signal arguments, ...) are shown " +
+			"as JSON instead of base64 data; binary payloads stay base64"
+	}
+
+	return registerTool(server, cfg, "GetWorkflowHistory", desc+namespaceDescription(cfg), namespacedHandler(tempClient, clients, cfg, getWorkflowHistoryHandler))
+}
+
+// emptyHistoryResponse is GetWorkflowHistory's response for a workflow Temporal can't find, with historyNotFoundAsEmpty
+func emptyHistoryResponse(workflowID string, err error) (*mcp.ToolResponse, error) {
+	log.Printf("No history found for workflow %s: %v", workflowID, err)
+	return mcp.NewToolResponse(mcp.NewTextContent("[]"), mcp.NewTextContent(fmt.Sprintf(
+		"Note: no history found for workflow %s - it may not exist, or it may have closed longer ago than the "+
+			"namespace's retention window", workflowID,
+	))), nil
+}
+
+func getWorkflowHistoryHandler(tempClient client.Client, cfg *config.Config) func(args GetWorkflowHistoryParams) (*mcp.ToolResponse, error) {
+	retry := newRetryPolicy(cfg.Temporal.RetryOptions)
+
+	return func(args GetWorkflowHistoryParams) (*mcp.ToolResponse, error) {
+		// Check if Temporal client is available
+		if tempClient == nil {
+			log.Printf("Error: Temporal client is not available for getting workflow histories")
+			return degradedResponse("Error: Temporal client is not available for getting workflow histories")
+		}
+
+		var notFound *serviceerror.NotFound
+		runID, err := resolveRun(context.Background(), tempClient, cfg, args.WorkflowID, args.RunID)
+		if err != nil && cfg.HistoryNotFoundAsEmpty && errors.As(err, &notFound) {
+			return emptyHistoryResponse(args.WorkflowID, err)
+		}
+		if err != nil {
+			msg := fmt.Sprintf("Error: %v", err)
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		projection, err := historyEventFields(args.Fields)
+		if err != nil {
+			return errorResponse(fmt.Sprintf("Error: %v", err))
+		}
+
+		events, fetchErr := fetchHistoryEvents(context.Background(), tempClient, args.WorkflowID, runID, retry)
+
+		total := len(events)
+		fetched := events
+		head := max(args.HeadEvents, 0)
+		events, omitted := headAndTailEvents(events, head, args.TailEvents)
+
+		marshaler := protojson.MarshalOptions{
+			// A projection leaves every other field unset, so emitting defaults would bring them back
+			EmitUnpopulated: args.EmitDefaults && len(projection) == 0,
+			UseProtoNames:   args.UseProtoFieldNames,
+		}
+		separator := ","
+		if args.Indent {
+			marshaler.Indent = "  "
+			separator = ",\n"
+		}
+		eventJsons := make([]string, 0, len(events)+1)
+		var stats sanitize_history_event.Stats
+		for i, event := range events {
+			if omitted > 0 && i == head {
+				eventJsons = append(eventJsons, fmt.Sprintf(`"...omitted %d events..."`, omitted))
+			}
+			if !cfg.HistoryIncludePayloads {
+				sanitize_history_event.SanitizeHistoryEventWithStats(event, &stats)
+			}
+			if len(projection) > 0 {
+				event = projectHistoryEvent(event, projection)
+			}
+			bytes, err := marshaler.Marshal(event)
+			if err != nil {
+				// should never happen?
+				return nil, err
+			}
+			if cfg.HistoryIncludePayloads && args.DecodePayloads {
+				if bytes, err = inlineJSONPayloads(bytes, marshaler.Indent); err != nil {
+					return nil, err
+				}
+			}
+
+			eventJsons = append(eventJsons, string(bytes))
+		}
+
+		// The last step of json-marshalling is unfortunate (forced on us by the lack of a proto for the list of
+		// events), but not worth actually building and marshalling a slice for. Let's just do it by hand.
+		allEvents := strings.Builder{}
+		if fetchErr != nil && total == 0 && cfg.HistoryNotFoundAsEmpty && errors.As(fetchErr, &notFound) {
+			return emptyHistoryResponse(args.WorkflowID, fetchErr)
+		}
+		if fetchErr != nil {
+			msg := fmt.Sprintf("Error: Failed to get %dth history event: %v", total, fetchErr)
+			log.Print(msg)
+			if total == 0 {
+				return errorResponse(msg)
+			}
+			allEvents.WriteString(msg)
+			allEvents.WriteString(fmt.Sprintf("\nThe %d events retrieved before the failure follow:\n", total))
+		}
+		allEvents.WriteString("[")
+		for i, eventJson := range eventJsons {
+			if i > 0 {
+				allEvents.WriteString(separator)
+			}
+			allEvents.WriteString(eventJson)
+		}
+		allEvents.WriteString("]")
+
+		var notes []*mcp.Content
+		if !cfg.HistoryIncludePayloads {
+			log.Printf("Sanitized history of workflow %s: %s", args.WorkflowID, stats)
+			if cfg.HistorySanitizationStats {
+				notes = append(notes, mcp.NewTextContent("Note: sanitization "+stats.String()))
+			}
+		}
+		if fetchErr != nil {
+			// A partial history is still a failed call
+			return errorResponse(allEvents.String(), notes...)
+		}
+		// A history too large to return falls back to its digest, like AnalyzeHistoryFile returns
+		if exceedsResponseLimit(cfg, allEvents.String()) {
+			bytes, err := json.Marshal(summarizeHistory(fetched))
+			if err != nil {
+				return nil, err
+			}
+			note := fmt.Sprintf("Note: the history of workflow %s is %d bytes, over the %d byte maxToolResponseBytes "+
+				"limit, so a summary of it is returned instead - use headEvents, tailEvents or fields to get events",
+				args.WorkflowID, allEvents.Len(), cfg.MaxToolResponseBytes)
+			contents := []*mcp.Content{mcp.NewTextContent(string(bytes)), mcp.NewTextContent(note)}
+			return mcp.NewToolResponse(append(contents, notes...)...), nil
+		}
+		return mcp.NewToolResponse(append([]*mcp.Content{mcp.NewTextContent(allEvents.String())}, notes...)...), nil
+	}
+}
+
+// historyEventFields looks up the HistoryEvent fields with the given names, which may be JSON (eventType) or proto
+// (event_type) names
+func historyEventFields(names []string) ([]protoreflect.FieldDescriptor, error) {
+	fields := (&history.HistoryEvent{}).ProtoReflect().Descriptor().Fields()
+	descriptors := make([]protoreflect.FieldDescriptor, 0, len(names))
+	for _, name := range names {
+		fd := fields.ByJSONName(name)
+		if fd == nil {
+			fd = fields.ByName(protoreflect.Name(name))
+		}
+		if fd == nil {
+			valid := make([]string, 0, fields.Len())
+			for i := 0; i < fields.Len(); i++ {
+				valid = append(valid, fields.Get(i).JSONName())
+			}
+			return nil, fmt.Errorf("unknown history event field %q - valid fields are %s", name, strings.Join(valid, ", "))
+		}
+		descriptors = append(descriptors, fd)
+	}
+	return descriptors, nil
+}
+
+// projectHistoryEvent returns a copy of the event with only the given fields set
+func projectHistoryEvent(event *history.HistoryEvent, fields []protoreflect.FieldDescriptor) *history.HistoryEvent {
+	source := event.ProtoReflect()
+	projected := &history.HistoryEvent{}
+	target := projected.ProtoReflect()
+	for _, fd := range fields {
+		if source.Has(fd) {
+			target.Set(fd, source.Get(fd))
+		}
+	}
+	return projected
+}
+
+// headAndTailEvents keeps the first head and last tail events, returning them and the number of events dropped in
+// between. With neither set, or when they cover every event, all events are kept.
+func headAndTailEvents(events []*history.HistoryEvent, head, tail int) ([]*history.HistoryEvent, int) {
+	head, tail = max(head, 0), max(tail, 0)
+	if (head == 0 && tail == 0) || head+tail >= len(events) {
+		return events, 0
+	}
+	kept := make([]*history.HistoryEvent, 0, head+tail)
+	kept = append(kept, events[:head]...)
+	kept = append(kept, events[len(events)-tail:]...)
+	return kept, len(events) - head - tail
+}
+
+// fetchHistoryEvents reads a workflow's full history. The SDK's iterator gives up after its first error, so transient
+// failures are retried by opening a new iterator and skipping the events already collected. On final failure the
+// events collected so far are returned alongside the error.
+func fetchHistoryEvents(ctx context.Context, tempClient client.Client, workflowID string, runID string, retry retryPolicy) ([]*history.HistoryEvent, error) {
+	events := make([]*history.HistoryEvent, 0)
+	for attempt := 1; ; attempt++ {
+		iterator := tempClient.GetWorkflowHistory(ctx, workflowID, runID, false, temporal_enums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+		seen := 0
+		var err error
+		for iterator.HasNext() {
+			var event *history.HistoryEvent
+			event, err = iterator.Next()
+			if err != nil {
+				break
+			}
+			seen++
+			if seen > len(events) {
+				events = append(events, event)
+			}
+		}
+		if err == nil {
+			return events, nil
+		}
+
+		if !isTransientError(err) || attempt >= retry.maxAttempts {
+			return events, err
+		}
+		delay := retry.backoff(attempt)
+		log.Printf("Transient error reading history of workflow %s after %d events (attempt %d/%d), retrying in %v: %v",
+			workflowID, len(events), attempt, retry.maxAttempts, delay, err)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return events, ctx.Err()
+		}
+	}
+}
+
+// isTransientError reports whether a Temporal service error is worth retrying
+func isTransientError(err error) bool {
+	var unavailable *serviceerror.Unavailable
+	var exhausted *serviceerror.ResourceExhausted
+	var deadlineExceeded *serviceerror.DeadlineExceeded
+	return errors.As(err, &unavailable) || errors.As(err, &exhausted) || errors.As(err, &deadlineExceeded)
+}
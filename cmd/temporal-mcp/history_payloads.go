@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// inlineJSONPayloads rewrites a protojson-marshalled history event so that payloads with a JSON encoding show their
+// decoded value in place of the base64 "data" blob. Binary payloads, and JSON payloads that don't decode, are left as
+// they are. The event is re-marshalled with encoding/json, which orders object keys alphabetically.
+func inlineJSONPayloads(eventJSON []byte, indent string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(eventJSON))
+	decoder.UseNumber()
+	var event any
+	if err := decoder.Decode(&event); err != nil {
+		return nil, err
+	}
+	event = inlinePayloadValues(event)
+	if indent != "" {
+		return json.MarshalIndent(event, "", indent)
+	}
+	return json.Marshal(event)
+}
+
+// inlinePayloadValues walks decoded JSON and replaces the data of every JSON payload with its decoded value
+func inlinePayloadValues(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		if decoded, ok := decodeJSONPayload(v); ok {
+			v["data"] = decoded
+			return v
+		}
+		for key, item := range v {
+			v[key] = inlinePayloadValues(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = inlinePayloadValues(item)
+		}
+	}
+	return value
+}
+
+// decodeJSONPayload returns the decoded data of a protojson Payload object ({"metadata": {"encoding": ...}, "data":
+// ...}) whose encoding is JSON, e.g. "json/plain" or "json/protobuf"
+func decodeJSONPayload(object map[string]any) (any, bool) {
+	metadata, ok := object["metadata"].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	encodedEncoding, ok := metadata["encoding"].(string)
+	if !ok {
+		return nil, false
+	}
+	encoding, err := base64.StdEncoding.DecodeString(encodedEncoding)
+	if err != nil || !strings.HasPrefix(string(encoding), "json/") {
+		return nil, false
+	}
+	encodedData, ok := object["data"].(string)
+	if !ok {
+		return nil, false
+	}
+	data, err := base64.StdEncoding.DecodeString(encodedData)
+	if err != nil {
+		return nil, false
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, false
+	}
+	return decoded, true
+}
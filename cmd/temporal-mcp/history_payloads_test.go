@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/history/v1"
+)
+
+func TestGetWorkflowHistoryDecodePayloads(t *testing.T) {
+	events := testHistoryEvents()
+	events[0].Attributes = &history.HistoryEvent_WorkflowExecutionStartedEventAttributes{
+		WorkflowExecutionStartedEventAttributes: &history.WorkflowExecutionStartedEventAttributes{
+			Input: &common.Payloads{Payloads: []*common.Payload{
+				{Metadata: map[string][]byte{"encoding": []byte("json/plain")}, Data: []byte(`{"orderId":"42","amount":12.5}`)},
+				{Metadata: map[string][]byte{"encoding": []byte("binary/plain")}, Data: []byte("raw")},
+			}},
+		},
+	}
+	cfg := fastRetryConfig()
+	cfg.HistoryIncludePayloads = true
+	get := func(args GetWorkflowHistoryParams) []map[string]any {
+		args.WorkflowID = "wf-1"
+		resp, err := getWorkflowHistoryHandler(&mockTemporalClient{historyEvents: events}, cfg)(args)
+		require.NoError(t, err)
+		var decoded []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &decoded))
+		return decoded
+	}
+	inputPayloads := func(event map[string]any) []any {
+		attrs := event["workflowExecutionStartedEventAttributes"].(map[string]any)
+		return attrs["input"].(map[string]any)["payloads"].([]any)
+	}
+
+	payloads := inputPayloads(get(GetWorkflowHistoryParams{DecodePayloads: true})[0])
+	require.Equal(t, map[string]any{"orderId": "42", "amount": 12.5}, payloads[0].(map[string]any)["data"],
+		"JSON payloads are inlined as objects")
+	require.Equal(t, "cmF3", payloads[1].(map[string]any)["data"], "binary payloads stay base64")
+
+	payloads = inputPayloads(get(GetWorkflowHistoryParams{})[0])
+	require.IsType(t, "", payloads[0].(map[string]any)["data"], "without decodePayloads, payloads are base64")
+
+	t.Run("indented", func(t *testing.T) {
+		payloads := inputPayloads(get(GetWorkflowHistoryParams{DecodePayloads: true, Indent: true})[0])
+		require.Equal(t, "42", payloads[0].(map[string]any)["data"].(map[string]any)["orderId"])
+	})
+}
+
+func TestInlineJSONPayloadsKeepsInvalidJSON(t *testing.T) {
+	// "json/plain" payload whose data ("not json") doesn't parse
+	event := `{"input":{"payloads":[{"metadata":{"encoding":"anNvbi9wbGFpbg=="},"data":"bm90IGpzb24="}]},"eventId":"1"}`
+	out, err := inlineJSONPayloads([]byte(event), "")
+	require.NoError(t, err)
+	require.JSONEq(t, event, string(out))
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	temporal_enums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/history/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+)
+
+// testHistoryEvents returns a short, complete workflow history
+func testHistoryEvents() []*history.HistoryEvent {
+	return []*history.HistoryEvent{
+		historyEvent(1, temporal_enums.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED),
+		historyEvent(2, temporal_enums.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED),
+		historyEvent(3, temporal_enums.EVENT_TYPE_WORKFLOW_TASK_STARTED),
+		historyEvent(4, temporal_enums.EVENT_TYPE_WORKFLOW_TASK_COMPLETED),
+		historyEvent(5, temporal_enums.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED),
+	}
+}
+
+// fastRetryConfig returns a config whose retries don't slow tests down
+func fastRetryConfig() *config.Config {
+	return &config.Config{
+		Temporal: config.TemporalConfig{
+			RetryOptions: config.RetryOptions{InitialInterval: "1ms", MaximumInterval: "5ms", MaximumAttempts: 3},
+		},
+	}
+}
+
+// historyResponseEventIDs parses the JSON array in a GetWorkflowHistory response and returns the event IDs
+func historyResponseEventIDs(t *testing.T, text string) []int64 {
+	var events []struct {
+		EventID string `json:"eventId"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(text[strings.Index(text, "["):]), &events))
+	ids := make([]int64, 0, len(events))
+	for _, event := range events {
+		var id int64
+		require.NoError(t, json.Unmarshal([]byte(event.EventID), &id))
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestGetWorkflowHistoryRetriesTransientErrors(t *testing.T) {
+	mockClient := &mockTemporalClient{
+		historyEvents: testHistoryEvents(),
+		historyErrs:   map[int]error{2: serviceerror.NewUnavailable("network blip")},
+	}
+
+	resp, err := getWorkflowHistoryHandler(mockClient, fastRetryConfig())(GetWorkflowHistoryParams{WorkflowID: "wf-1"})
+	require.NoError(t, err)
+
+	text := resp.Content[0].TextContent.Text
+	require.True(t, strings.HasPrefix(text, "["), "expected a clean event array, got: %s", text)
+	require.Equal(t, []int64{1, 2, 3, 4, 5}, historyResponseEventIDs(t, text))
+	require.Equal(t, 2, mockClient.historyCalls)
+}
+
+func TestGetWorkflowHistoryReturnsPartialEventsOnFailure(t *testing.T) {
+	mockClient := &mockTemporalClient{
+		historyEvents: testHistoryEvents(),
+		historyErrs:   map[int]error{3: serviceerror.NewInvalidArgument("bad request")},
+	}
+
+	resp, err := asResponse(getWorkflowHistoryHandler(mockClient, fastRetryConfig())(GetWorkflowHistoryParams{WorkflowID: "wf-1"}))
+	require.NoError(t, err)
+
+	text := resp.Content[0].TextContent.Text
+	require.Contains(t, text, "Error: Failed to get 3th history event: bad request")
+	require.Equal(t, []int64{1, 2, 3}, historyResponseEventIDs(t, text))
+	require.Equal(t, 1, mockClient.historyCalls, "non-transient errors must not be retried")
+}
+
+func TestGetWorkflowHistoryNotFound(t *testing.T) {
+	// Each mock needs its own map - the mock consumes errors as it returns them
+	notFound := func() map[int]error {
+		return map[int]error{0: serviceerror.NewNotFound("workflow execution not found")}
+	}
+
+	t.Run("error by default", func(t *testing.T) {
+		mockClient := &mockTemporalClient{historyEvents: testHistoryEvents(), historyErrs: notFound()}
+		resp, err := asResponse(getWorkflowHistoryHandler(mockClient, fastRetryConfig())(GetWorkflowHistoryParams{WorkflowID: "wf-1"}))
+		require.NoError(t, err)
+		require.Len(t, resp.Content, 1)
+		require.Contains(t, resp.Content[0].TextContent.Text, "Error: Failed to get 0th history event: workflow execution not found")
+	})
+
+	t.Run("empty with historyNotFoundAsEmpty", func(t *testing.T) {
+		cfg := fastRetryConfig()
+		cfg.HistoryNotFoundAsEmpty = true
+		mockClient := &mockTemporalClient{historyEvents: testHistoryEvents(), historyErrs: notFound()}
+		resp, err := getWorkflowHistoryHandler(mockClient, cfg)(GetWorkflowHistoryParams{WorkflowID: "wf-1"})
+		require.NoError(t, err)
+		require.Len(t, resp.Content, 2)
+		require.Equal(t, "[]", resp.Content[0].TextContent.Text)
+		require.Contains(t, resp.Content[1].TextContent.Text, "no history found for workflow wf-1")
+		require.Contains(t, resp.Content[1].TextContent.Text, "retention window")
+	})
+
+	t.Run("empty when the first run can't be found", func(t *testing.T) {
+		cfg := fastRetryConfig()
+		cfg.HistoryNotFoundAsEmpty = true
+		cfg.DefaultRunSelector = config.RunSelectorFirst
+		mockClient := &mockTemporalClient{describeErr: serviceerror.NewNotFound("workflow execution not found")}
+		resp, err := getWorkflowHistoryHandler(mockClient, cfg)(GetWorkflowHistoryParams{WorkflowID: "wf-1"})
+		require.NoError(t, err)
+		require.Len(t, resp.Content, 2)
+		require.Equal(t, "[]", resp.Content[0].TextContent.Text)
+	})
+
+	t.Run("other errors are still reported", func(t *testing.T) {
+		cfg := fastRetryConfig()
+		cfg.HistoryNotFoundAsEmpty = true
+		mockClient := &mockTemporalClient{
+			historyEvents: testHistoryEvents(),
+			historyErrs:   map[int]error{0: serviceerror.NewInvalidArgument("bad request")},
+		}
+		resp, err := asResponse(getWorkflowHistoryHandler(mockClient, cfg)(GetWorkflowHistoryParams{WorkflowID: "wf-1"}))
+		require.NoError(t, err)
+		require.Contains(t, resp.Content[0].TextContent.Text, "Error: Failed to get 0th history event: bad request")
+	})
+}
+
+func TestFetchHistoryEventsGivesUpAfterMaxAttempts(t *testing.T) {
+	unavailable := serviceerror.NewUnavailable("down")
+	mockClient := &mockTemporalClient{
+		historyEvents: testHistoryEvents(),
+		historyErrs:   map[int]error{0: unavailable},
+	}
+	// Re-arm the error for every attempt
+	policy := newRetryPolicy(fastRetryConfig().Temporal.RetryOptions)
+	events, err := fetchHistoryEvents(context.Background(), &rearmingHistoryClient{mockClient, unavailable}, "wf-1", "", policy)
+	require.True(t, errors.Is(err, unavailable))
+	require.Empty(t, events)
+	require.Equal(t, policy.maxAttempts, mockClient.historyCalls)
+}
+
+// rearmingHistoryClient fails the first history event on every call
+type rearmingHistoryClient struct {
+	*mockTemporalClient
+	err error
+}
+
+func (c *rearmingHistoryClient) GetWorkflowHistory(ctx context.Context, workflowID string, runID string, isLongPoll bool, filterType temporal_enums.HistoryEventFilterType) client.HistoryEventIterator {
+	c.historyErrs = map[int]error{0: c.err}
+	return c.mockTemporalClient.GetWorkflowHistory(ctx, workflowID, runID, isLongPoll, filterType)
+}
+
+func TestGetWorkflowHistoryHeadAndTail(t *testing.T) {
+	mockClient := &mockTemporalClient{historyEvents: testHistoryEvents()}
+	handler := getWorkflowHistoryHandler(mockClient, fastRetryConfig())
+
+	parse := func(text string) []string {
+		var elements []json.RawMessage
+		require.NoError(t, json.Unmarshal([]byte(text), &elements))
+		var summary []string
+		for _, element := range elements {
+			var marker string
+			if json.Unmarshal(element, &marker) == nil {
+				summary = append(summary, marker)
+				continue
+			}
+			var event struct {
+				EventID string `json:"eventId"`
+			}
+			require.NoError(t, json.Unmarshal(element, &event))
+			summary = append(summary, event.EventID)
+		}
+		return summary
+	}
+
+	resp, err := handler(GetWorkflowHistoryParams{WorkflowID: "wf-1", HeadEvents: 1, TailEvents: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "...omitted 2 events...", "4", "5"}, parse(resp.Content[0].TextContent.Text))
+
+	resp, err = handler(GetWorkflowHistoryParams{WorkflowID: "wf-1", TailEvents: 1})
+	require.NoError(t, err)
+	require.Equal(t, []string{"...omitted 4 events...", "5"}, parse(resp.Content[0].TextContent.Text))
+
+	// Nothing to elide when head and tail cover the whole history
+	resp, err = handler(GetWorkflowHistoryParams{WorkflowID: "wf-1", HeadEvents: 3, TailEvents: 3})
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2", "3", "4", "5"}, parse(resp.Content[0].TextContent.Text))
+}
+
+func TestGetWorkflowHistorySanitizationStats(t *testing.T) {
+	cfg := fastRetryConfig()
+	resp, err := getWorkflowHistoryHandler(&mockTemporalClient{historyEvents: testHistoryEvents()}, cfg)(GetWorkflowHistoryParams{WorkflowID: "wf-1"})
+	require.NoError(t, err)
+	require.Len(t, resp.Content, 1, "stats are only logged by default")
+
+	cfg.HistorySanitizationStats = true
+	resp, err = getWorkflowHistoryHandler(&mockTemporalClient{historyEvents: testHistoryEvents()}, cfg)(GetWorkflowHistoryParams{WorkflowID: "wf-1"})
+	require.NoError(t, err)
+	require.Len(t, resp.Content, 2)
+	require.Regexp(t, `^Note: sanitization removed 0 payload bytes from 0 of 5 events \(\d+ -> \d+ bytes, ratio 1\.00\)$`,
+		resp.Content[1].TextContent.Text)
+}
+
+func TestGetWorkflowHistoryMarshalOptions(t *testing.T) {
+	get := func(args GetWorkflowHistoryParams) string {
+		args.WorkflowID = "wf-1"
+		resp, err := getWorkflowHistoryHandler(&mockTemporalClient{historyEvents: testHistoryEvents()}, fastRetryConfig())(args)
+		require.NoError(t, err)
+		return resp.Content[0].TextContent.Text
+	}
+
+	compact := get(GetWorkflowHistoryParams{})
+	require.NotContains(t, compact, "\n")
+	require.Contains(t, compact, `"eventId"`)
+	require.NotContains(t, compact, `"event_id"`)
+	require.NotContains(t, compact, `"taskId"`, "zero-valued fields are omitted by default")
+
+	indented := get(GetWorkflowHistoryParams{Indent: true, UseProtoFieldNames: true})
+	require.Contains(t, indented, "\n  \"event_id\"")
+	require.NotContains(t, indented, `"eventId"`)
+	var events []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(indented), &events), "indented output is still a JSON array")
+	require.Len(t, events, 5)
+
+	require.Contains(t, get(GetWorkflowHistoryParams{EmitDefaults: true}), `"taskId"`)
+}
+
+func TestGetWorkflowHistoryFieldProjection(t *testing.T) {
+	events := testHistoryEvents()
+	events[0].EventTime = timestamppb.New(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	events[0].TaskId = 42
+	events[0].Attributes = &history.HistoryEvent_WorkflowExecutionStartedEventAttributes{
+		WorkflowExecutionStartedEventAttributes: &history.WorkflowExecutionStartedEventAttributes{Identity: "worker-1"},
+	}
+	get := func(fields []string) string {
+		resp, err := asResponse(getWorkflowHistoryHandler(&mockTemporalClient{historyEvents: events}, fastRetryConfig())(
+			GetWorkflowHistoryParams{WorkflowID: "wf-1", Fields: fields, EmitDefaults: true}))
+		require.NoError(t, err)
+		return resp.Content[0].TextContent.Text
+	}
+
+	var projected []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(get([]string{"eventId", "event_type", "eventTime"})), &projected))
+	require.Len(t, projected, 5)
+	require.Equal(t, map[string]any{
+		"eventId":   "1",
+		"eventType": "EVENT_TYPE_WORKFLOW_EXECUTION_STARTED",
+		"eventTime": "2025-03-01T12:00:00Z",
+	}, projected[0])
+	require.Equal(t, map[string]any{"eventId": "2", "eventType": "EVENT_TYPE_WORKFLOW_TASK_SCHEDULED"}, projected[1],
+		"unset fields stay out even with emitDefaults")
+
+	require.Contains(t, get([]string{"workflowExecutionStartedEventAttributes"}), `"identity":"worker-1"`)
+	require.Contains(t, get(nil), `"taskId":"42"`, "without fields, events are complete")
+
+	require.True(t, strings.HasPrefix(get([]string{"eventId", "evenType"}), `Error: unknown history event field "evenType" - valid fields are eventId, eventTime, eventType,`))
+}
+
+func TestOversizedHistoryFallsBackToSummary(t *testing.T) {
+	mockClient := &mockTemporalClient{historyEvents: testHistoryEvents()}
+	cfg := &config.Config{MaxToolResponseBytes: 200}
+
+	resp, err := getWorkflowHistoryHandler(mockClient, cfg)(GetWorkflowHistoryParams{WorkflowID: "order_1", RunID: "run-1"})
+	require.NoError(t, err)
+	require.Len(t, resp.Content, 2)
+
+	var digest historyDigest
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &digest))
+	require.Equal(t, 5, digest.EventCount)
+	require.Equal(t, "Completed", digest.Status)
+	require.Contains(t, resp.Content[1].TextContent.Text, "history of workflow order_1")
+	require.Contains(t, resp.Content[1].TextContent.Text, "use headEvents, tailEvents or fields")
+
+	// Under the limit, the events themselves are returned
+	resp, err = getWorkflowHistoryHandler(mockClient, &config.Config{MaxToolResponseBytes: 100000})(GetWorkflowHistoryParams{WorkflowID: "order_1", RunID: "run-1"})
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2, 3, 4, 5}, historyResponseEventIDs(t, resp.Content[0].TextContent.Text))
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mocksi/temporal-mcp/internal/schema"
+)
+
+// validateInputSchema checks params against a workflow's input JSON Schema. Params are always strings, so each value
+// is first converted to the type its property declares - numbers, booleans, and JSON-encoded objects and arrays -
+// and left as a string when it doesn't parse, so the mismatch is reported. The converted params are returned too:
+// they're what the workflow receives as its input.
+func validateInputSchema(inputSchema map[string]any, params map[string]string) (map[string]any, []schema.Violation) {
+	properties, _ := inputSchema["properties"].(map[string]any)
+	typed := make(map[string]any, len(params))
+	for key, value := range params {
+		propertySchema, _ := properties[key].(map[string]any)
+		typed[key] = typedParamValue(propertySchema, value)
+	}
+	return typed, schema.Validate(inputSchema, typed)
+}
+
+// joinViolations renders schema violations for error and warning messages
+func joinViolations(violations []schema.Violation) string {
+	messages := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		messages = append(messages, violation.String())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// typedParamValue converts a param to the first non-string type its schema allows that the value parses as
+func typedParamValue(propertySchema map[string]any, value string) any {
+	var types []string
+	switch t := propertySchema["type"].(type) {
+	case string:
+		types = []string{t}
+	case []any:
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+	}
+	for _, t := range types {
+		switch t {
+		case "string":
+			return value
+		case "number", "integer":
+			if n, err := strconv.ParseFloat(value, 64); err == nil {
+				return n
+			}
+		case "boolean":
+			if b, err := strconv.ParseBool(value); err == nil {
+				return b
+			}
+		case "object", "array", "null":
+			var decoded any
+			if err := json.Unmarshal([]byte(value), &decoded); err == nil {
+				return decoded
+			}
+		}
+	}
+	return value
+}
+
+// inputSchemaProperties returns the sorted names of the top-level properties of an input JSON Schema
+func inputSchemaProperties(inputSchema map[string]any) []string {
+	properties, _ := inputSchema["properties"].(map[string]any)
+	return slices.Sorted(maps.Keys(properties))
+}
+
+// inputSchemaDescription documents an input JSON Schema in tool descriptions and the system prompt
+func inputSchemaDescription(inputSchema map[string]any) string {
+	bytes, err := json.MarshalIndent(inputSchema, "", "  ")
+	if err != nil {
+		// Schemas are decoded from JSON or YAML, so they always re-encode
+		return ""
+	}
+	return "Params must match this JSON Schema (pass object and array values as JSON-encoded strings; the workflow " +
+		"receives them decoded):\n```json\n" +
+		string(bytes) + "\n```\n"
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowInputSchemaFile(t *testing.T) {
+	schemaPath, err := filepath.Abs(filepath.Join("test_data", "order_input_schema.json"))
+	require.NoError(t, err)
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+workflows:
+  OrderWorkflow:
+    purpose: "Places an order"
+    workflowIDRecipe: "order_{{ .order_id }}"
+    taskQueue: "orders"
+    inputSchemaFile: "`+schemaPath+`"
+    input:
+      type: "OrderRequest"
+      fields:
+        - order_id: "The order ID"
+`), 0o600))
+	cfg, err := config.LoadConfig(configPath)
+	require.NoError(t, err)
+	workflow := cfg.Workflows["OrderWorkflow"]
+	require.Equal(t, "object", workflow.Input.Schema["type"])
+
+	var mockClient *mockTemporalClient
+	call := func(params map[string]string) string {
+		mockClient = &mockTemporalClient{runResult: "placed"}
+		resp, err := asResponse(workflowToolHandler("OrderWorkflow", workflow, mockClient, cfg)(WorkflowParams{Params: params}))
+		require.NoError(t, err)
+		return resp.Content[0].TextContent.Text
+	}
+
+	require.Equal(t, "placed", call(map[string]string{
+		"order_id": "ORD-7",
+		"quantity": "2",
+		"shipping": `{"express": true, "address": {"street": "1 Main St", "zip": "94105"}}`,
+	}))
+	// The workflow receives the values the schema types, not their JSON text
+	require.Equal(t, []any{map[string]any{
+		"order_id": "ORD-7",
+		"quantity": float64(2),
+		"shipping": map[string]any{"express": true, "address": map[string]any{"street": "1 Main St", "zip": "94105"}},
+	}}, mockClient.lastWorkflowArgs)
+
+	require.Equal(t, "Error: Invalid parameters for workflow OrderWorkflow: "+
+		`$.quantity: 0 is less than the minimum 1; `+
+		`$.shipping.address: missing required property "zip"; `+
+		`$.shipping.express: expected boolean, got string`,
+		call(map[string]string{
+			"order_id": "ORD-7",
+			"quantity": "0",
+			"shipping": `{"express": "yes", "address": {"street": "1 Main St"}}`,
+		}))
+
+	require.Equal(t, `Error: Invalid parameters for workflow OrderWorkflow: $: missing required property "shipping"; `+
+		`$: unexpected property "shipping_address"`,
+		call(map[string]string{"order_id": "ORD-7", "shipping_address": "1 Main St"}))
+
+	t.Run("schema is advertised", func(t *testing.T) {
+		prompt := buildSystemPrompt(cfg)
+		require.Contains(t, prompt, "Params must match this JSON Schema")
+		require.Contains(t, prompt, `"zip": {`)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(configPath, []byte(`
+workflows:
+  OrderWorkflow:
+    inputSchemaFile: "missing.json"
+`), 0o600))
+		_, err := config.LoadConfig(configPath)
+		require.ErrorContains(t, err, "workflow OrderWorkflow: inputSchemaFile: open "+filepath.Join(filepath.Dir(configPath), "missing.json"))
+	})
+}
@@ -1,33 +1,42 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"maps"
+	"net/url"
 	"os"
 	"os/signal"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"syscall"
-	"text/template"
-
-	"github.com/google/uuid"
-	"github.com/mocksi/temporal-mcp/internal/sanitize_history_event"
-	"google.golang.org/protobuf/encoding/protojson"
+	"time"
 
 	mcp "github.com/metoro-io/mcp-golang"
 	mcphttp "github.com/metoro-io/mcp-golang/transport/http"
 	"github.com/mocksi/temporal-mcp/internal/config"
+	"github.com/mocksi/temporal-mcp/internal/schema"
 	"github.com/mocksi/temporal-mcp/internal/temporal"
 	temporal_enums "go.temporal.io/api/enums/v1"
 	"go.temporal.io/sdk/client"
+	temporal_sdk "go.temporal.io/sdk/temporal"
 )
 
 func main() {
 	// Parse command line arguments
 	configFile := flag.String("config", "config.yml", "Path to configuration file")
 	port := flag.String("port", "", "Port to listen on (overrides PORT env var)")
+	namespace := flag.String("namespace", "", "Temporal namespace (overrides temporal.namespace in the config)")
 	flag.Parse()
+	startTime := time.Now()
 
 	// Configure logger to write to stderr
 	log.SetOutput(os.Stderr)
@@ -43,18 +52,33 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 	log.Printf("Loaded configuration with %d workflows", len(cfg.Workflows))
+	cfg.Temporal.Namespace = resolveNamespace(*namespace, cfg.Temporal.Namespace)
+	log.Printf("Using Temporal namespace %s", cfg.Temporal.Namespace)
 
 	// Initialize Temporal client
 	var temporalClient client.Client
 	var temporalError error
 
-	temporalClient, temporalError = temporal.NewTemporalClient(cfg.Temporal)
+	// Workflows in other namespaces get their own clients from the pool; identical connections are shared
+	clients := temporal.NewClientPool()
+	defer clients.Close()
+
+	temporalClient, temporalError = clients.Get(cfg.Temporal)
 	if temporalError != nil {
 		log.Printf("WARNING: Failed to connect to Temporal service: %v", temporalError)
 		log.Printf("MCP will run in degraded mode - workflow executions will return errors")
 	} else {
-		defer temporalClient.Close()
 		log.Printf("Connected to Temporal service at %s", cfg.Temporal.HostPort)
+
+		if cfg.Temporal.MinRetention != "" {
+			checkNamespaceRetention(temporalClient, cfg)
+		}
+
+		if cfg.CheckTaskQueuePollers {
+			warnIdleTaskQueues(context.Background(), func(workflow config.WorkflowDef) client.Client {
+				return workflowClient(clients, cfg, workflow)
+			}, cfg)
+		}
 	}
 
 	// Determine port to listen on
@@ -74,18 +98,141 @@ func main() {
 
 	// Register all workflow tools (non-fatal if Temporal unavailable)
 	log.Println("Registering workflow tools...")
-	err = registerWorkflowTools(server, cfg, temporalClient)
+	err = registerWorkflowTools(server, cfg, clients)
+	if errors.Is(err, errTooManyWorkflows) || errors.Is(err, errNoTaskQueue) {
+		log.Fatalf("Failed to register workflow tools: %v", err)
+	}
 	if err != nil {
 		log.Printf("WARNING: Failed to register workflow tools: %v", err)
 		log.Printf("Server will start without workflow tools - configure Temporal connection to enable full functionality")
 	}
 
+	// Register tools for workflows discovered on the server but missing from config (opt-in)
+	if cfg.DiscoverWorkflows && temporalClient != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), discoveryTimeout)
+		_, err = registerDiscoveredWorkflowTools(ctx, server, cfg, temporalClient, scheduleDiscoverySource{client: temporalClient})
+		cancel()
+		if err != nil {
+			log.Printf("WARNING: Failed to discover workflows: %v", err)
+		}
+	}
+
 	// Register get workflow history tool (non-fatal if Temporal unavailable)
-	err = registerGetWorkflowHistoryTool(server, temporalClient)
+	err = registerGetWorkflowHistoryTool(server, temporalClient, clients, cfg)
 	if err != nil {
 		log.Printf("WARNING: Failed to register get workflow history tool: %v", err)
 	}
 
+	// Register cancel and terminate workflow tools (non-fatal if Temporal unavailable)
+	err = registerCancelWorkflowTool(server, temporalClient, clients, cfg)
+	if err != nil {
+		log.Printf("WARNING: Failed to register cancel workflow tool: %v", err)
+	}
+	err = registerTerminateWorkflowTool(server, temporalClient, clients, cfg)
+	if err != nil {
+		log.Printf("WARNING: Failed to register terminate workflow tool: %v", err)
+	}
+
+	// Register get workflow trace tool (non-fatal if Temporal unavailable)
+	err = registerGetWorkflowTraceTool(server, temporalClient, clients, cfg)
+	if err != nil {
+		log.Printf("WARNING: Failed to register get workflow trace tool: %v", err)
+	}
+
+	// Register tail workflow tool (non-fatal if Temporal unavailable)
+	err = registerTailWorkflowTool(server, temporalClient, clients, cfg)
+	if err != nil {
+		log.Printf("WARNING: Failed to register tail workflow tool: %v", err)
+	}
+
+	// Register get workflow stack trace tool (non-fatal if Temporal unavailable)
+	err = registerGetWorkflowStackTraceTool(server, temporalClient, clients, cfg)
+	if err != nil {
+		log.Printf("WARNING: Failed to register get workflow stack trace tool: %v", err)
+	}
+
+	// Register get workflow attributes tool (non-fatal if Temporal unavailable)
+	err = registerGetWorkflowAttributesTool(server, temporalClient, clients, cfg)
+	if err != nil {
+		log.Printf("WARNING: Failed to register get workflow attributes tool: %v", err)
+	}
+
+	err = registerQueryWorkflowTool(server, temporalClient, clients, cfg)
+	if err != nil {
+		log.Printf("WARNING: Failed to register query workflow tool: %v", err)
+	}
+
+	err = registerSignalWorkflowTool(server, temporalClient, clients, cfg)
+	if err != nil {
+		log.Printf("WARNING: Failed to register signal workflow tool: %v", err)
+	}
+
+	err = registerGetPendingActivitiesTool(server, temporalClient, clients, cfg)
+	if err != nil {
+		log.Printf("WARNING: Failed to register get pending activities tool: %v", err)
+	}
+
+	// Register batch describe and wait for status tools (non-fatal if Temporal unavailable)
+	err = registerBatchDescribeWorkflowsTool(server, temporalClient, cfg)
+	if err != nil {
+		log.Printf("WARNING: Failed to register batch describe workflows tool: %v", err)
+	}
+
+	err = registerWaitForStatusTool(server, temporalClient, clients, cfg)
+	if err != nil {
+		log.Printf("WARNING: Failed to register wait for status tool: %v", err)
+	}
+
+	err = registerCountWorkflowsTool(server, temporalClient, cfg)
+	if err != nil {
+		log.Printf("WARNING: Failed to register count workflows tool: %v", err)
+	}
+
+	err = registerDescribeTaskQueueTool(server, temporalClient, cfg)
+	if err != nil {
+		log.Printf("WARNING: Failed to register describe task queue tool: %v", err)
+	}
+
+	// Register describe schedule tool (non-fatal if Temporal unavailable)
+	err = registerDescribeScheduleTool(server, temporalClient, cfg)
+	if err != nil {
+		log.Printf("WARNING: Failed to register describe schedule tool: %v", err)
+	}
+
+	// Register pause/unpause schedule tools (non-fatal if Temporal unavailable)
+	err = registerPauseScheduleTools(server, temporalClient, cfg)
+	if err != nil {
+		log.Printf("WARNING: Failed to register pause schedule tools: %v", err)
+	}
+
+	// Register batch terminate tool (destructive, so only when explicitly enabled)
+	if cfg.EnableBatchTerminate {
+		err = registerBatchTerminateTool(server, temporalClient, cfg)
+		if err != nil {
+			log.Printf("WARNING: Failed to register batch terminate tool: %v", err)
+		}
+	}
+
+	// Register analyze history file tool (works without Temporal; only when a history file directory is configured)
+	if cfg.HistoryFileDir != "" {
+		err = registerAnalyzeHistoryFileTool(server, cfg)
+		if err != nil {
+			log.Printf("WARNING: Failed to register analyze history file tool: %v", err)
+		}
+	}
+
+	// Register ping tool (reports degraded health if Temporal unavailable)
+	err = registerPingTool(server, cfg, temporalClient, startTime)
+	if err != nil {
+		log.Printf("WARNING: Failed to register ping tool: %v", err)
+	}
+
+	// Register tool schemas tool last, so it describes every tool (this should always work)
+	err = registerGetToolSchemasTool(server, cfg)
+	if err != nil {
+		log.Printf("WARNING: Failed to register get tool schemas tool: %v", err)
+	}
+
 	// Register system prompt (this should always work)
 	err = registerSystemPrompt(server, cfg)
 	if err != nil {
@@ -109,11 +256,61 @@ func main() {
 	log.Printf("Temporal MCP HTTP server has been stopped.")
 }
 
-// registerWorkflowTools registers all workflow definitions as MCP tools
-func registerWorkflowTools(server *mcp.Server, cfg *config.Config, tempClient client.Client) error {
+// checkNamespaceRetention logs a warning when the namespace's retention is shorter than cfg.Temporal.MinRetention
+func checkNamespaceRetention(tempClient client.Client, cfg *config.Config) {
+	minRetention, err := time.ParseDuration(cfg.Temporal.MinRetention)
+	if err != nil {
+		log.Printf("WARNING: Ignoring invalid temporal.minRetention %q: %v", cfg.Temporal.MinRetention, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	warning, err := namespaceRetentionWarning(ctx, tempClient, cfg.Temporal.Namespace, minRetention)
+	if err != nil {
+		log.Printf("WARNING: Could not check namespace retention: %v", err)
+		return
+	}
+	if warning != "" {
+		log.Print(warning)
+	}
+}
+
+// defaultMaxWorkflows is the number of workflow tools registered when cfg.MaxWorkflows is unset
+const defaultMaxWorkflows = 500
+
+// errTooManyWorkflows is returned by registerWorkflowTools when the config declares more workflows than allowed
+var errTooManyWorkflows = errors.New("too many workflows")
+
+// errNoTaskQueue is returned when registering a workflow that has no task queue and no default task queue to fall back
+// on; every execution would fail
+var errNoTaskQueue = errors.New("no task queue")
+
+// registerWorkflowTools registers a tool per configured workflow, each using the pool's client for the workflow's
+// namespace. With a nil pool, tools are registered without a client, as when Temporal is unavailable.
+func registerWorkflowTools(server *mcp.Server, cfg *config.Config, clients *temporal.ClientPool) error {
+	names := make([]string, 0, len(cfg.Workflows))
+	for name := range cfg.Workflows {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Each workflow becomes a tool; a malformed or generated config can declare more than a client can handle
+	maxWorkflows := maxWorkflowTools(cfg)
+	if len(names) > maxWorkflows {
+		if cfg.TruncateWorkflows {
+			log.Printf("WARNING: %d workflows are configured but maxWorkflows is %d - registering only the first %d (by name)",
+				len(names), maxWorkflows, maxWorkflows)
+			names = names[:maxWorkflows]
+		} else {
+			return fmt.Errorf("%w: %d are configured but maxWorkflows is %d", errTooManyWorkflows, len(names), maxWorkflows)
+		}
+	}
+
 	// Register all workflows as tools
-	for name, workflow := range cfg.Workflows {
-		err := registerWorkflowTool(server, name, workflow, tempClient, cfg)
+	for _, name := range names {
+		workflow := cfg.Workflows[name]
+		err := registerWorkflowTool(server, name, workflow, workflowClient(clients, cfg, workflow), cfg)
 		if err != nil {
 			return fmt.Errorf("failed to register workflow tool %s: %w", name, err)
 		}
@@ -123,18 +320,129 @@ func registerWorkflowTools(server *mcp.Server, cfg *config.Config, tempClient cl
 	return nil
 }
 
-// registerWorkflowTool registers a single workflow as an MCP tool
-func registerWorkflowTool(server *mcp.Server, name string, workflow config.WorkflowDef, tempClient client.Client, cfg *config.Config) error {
-	// Define the type for workflow parameters based on fields
-	type WorkflowParams struct {
-		Params     map[string]string `json:"params"`
-		ForceRerun bool              `json:"force_rerun"`
+// maxWorkflowTools returns the limit on workflow tools, configured and discovered together
+func maxWorkflowTools(cfg *config.Config) int {
+	if cfg.MaxWorkflows <= 0 {
+		return defaultMaxWorkflows
+	}
+	return cfg.MaxWorkflows
+}
+
+// workflowClient returns the pool's client for the workflow's namespace (the configured namespace unless the workflow
+// overrides it), or nil when there is no pool or the connection failed
+func workflowClient(clients *temporal.ClientPool, cfg *config.Config, workflow config.WorkflowDef) client.Client {
+	if clients == nil {
+		return nil
+	}
+	connection := cfg.Temporal
+	if workflow.Namespace != "" {
+		connection.Namespace = workflow.Namespace
+	}
+	tempClient, err := clients.Get(connection)
+	if err != nil {
+		// Failures of the default connection were already reported at startup
+		if workflow.Namespace != "" {
+			log.Printf("WARNING: Failed to connect to Temporal namespace %s: %v", workflow.Namespace, err)
+		}
+		return nil
+	}
+	return tempClient
+}
+
+// WorkflowParams are the arguments of every workflow tool
+type WorkflowParams struct {
+	Params           ParamsMap `json:"params"`
+	ForceRerun       *bool     `json:"force_rerun"` // nil when omitted, so the workflow's defaultForceRerun applies
+	RunTimeout       string    `json:"run_timeout,omitempty"`
+	ExecutionTimeout string    `json:"execution_timeout,omitempty"`
+	Async            bool      `json:"async,omitempty"` // Return the workflow and run IDs once started instead of waiting
+}
+
+// startedWorkflow is the response of an async workflow tool call
+type startedWorkflow struct {
+	WorkflowID string `json:"workflowId"`
+	RunID      string `json:"runId"`
+	Namespace  string `json:"namespace,omitempty"` // Set when the workflow runs outside the configured namespace
+}
+
+// ParamsMap holds a workflow tool's params. LLMs often send the whole object as a JSON-encoded string instead of an
+// object, or send numbers and booleans unquoted; both are accepted transparently.
+type ParamsMap map[string]string
+
+// UnmarshalJSON accepts either a JSON object or a string containing one. Number and boolean values are converted to
+// their JSON text (42, 1.5, true); null values decode to "", like missing ones.
+func (p *ParamsMap) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var encoded string
+		if err := json.Unmarshal(data, &encoded); err != nil {
+			return err
+		}
+		params, err := decodeParams([]byte(encoded))
+		if err != nil {
+			return fmt.Errorf("params must be an object (or a string containing a JSON object): %w", err)
+		}
+		log.Printf("Decoded params passed as a JSON-encoded string")
+		*p = params
+		return nil
 	}
 
+	params, err := decodeParams(data)
+	if err != nil {
+		return err
+	}
+	*p = params
+	return nil
+}
+
+// decodeParams decodes a JSON object of params, converting scalar values that aren't strings to strings
+func decodeParams(data []byte) (ParamsMap, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	params := make(ParamsMap, len(raw))
+	var coerced []string
+	for key, value := range raw {
+		var str string
+		if err := json.Unmarshal(value, &str); err == nil {
+			params[key] = str
+			continue
+		}
+
+		var decoded interface{}
+		decoder := json.NewDecoder(bytes.NewReader(value))
+		decoder.UseNumber()
+		if err := decoder.Decode(&decoded); err != nil {
+			return nil, err
+		}
+		switch v := decoded.(type) {
+		case json.Number:
+			params[key] = v.String()
+		case bool:
+			params[key] = strconv.FormatBool(v)
+		default:
+			return nil, fmt.Errorf("param %s must be a string, number or boolean, not an object or array", key)
+		}
+		coerced = append(coerced, key)
+	}
+	if len(coerced) > 0 {
+		sort.Strings(coerced)
+		log.Printf("Warning: converted non-string values of params %s to strings", strings.Join(coerced, ", "))
+	}
+	return params, nil
+}
+
+// registerWorkflowTool registers a single workflow as an MCP tool
+func registerWorkflowTool(server *mcp.Server, name string, workflow config.WorkflowDef, tempClient client.Client, cfg *config.Config) error {
 	// Build detailed parameter descriptions for tool registration
 	paramDescriptions := "\n\n**Parameters:**\n"
 	for _, field := range workflow.Input.Fields {
-		for fieldName, description := range field {
+		for _, fieldName := range slices.Sorted(maps.Keys(field)) {
+			description := field[fieldName]
 			isRequired := !strings.Contains(description, "Optional")
 			if isRequired {
 				paramDescriptions += fmt.Sprintf("- `%s` (required): %s\n", fieldName, description)
@@ -144,67 +452,206 @@ func registerWorkflowTool(server *mcp.Server, name string, workflow config.Workf
 		}
 	}
 
-	// Add example usage
-	paramDescriptions += "\n**Example Usage:**\n```json\n{\n  \"params\": {\n"
-	paramExamples := []string{}
-	for _, field := range workflow.Input.Fields {
-		for fieldName, _ := range field {
-			if strings.Contains(fieldName, "json") {
-				paramExamples = append(paramExamples, fmt.Sprintf("    \"%s\": {\"example\": \"value\"}", fieldName))
-			} else if strings.Contains(fieldName, "id") {
-				paramExamples = append(paramExamples, fmt.Sprintf("    \"%s\": \"example-id-123\"", fieldName))
-			} else {
-				paramExamples = append(paramExamples, fmt.Sprintf("    \"%s\": \"example value\"", fieldName))
-			}
-		}
+	if len(workflow.Input.Schema) > 0 {
+		paramDescriptions += "\n" + inputSchemaDescription(workflow.Input.Schema)
+	}
+
+	if workflow.DefaultForceRerun {
+		paramDescriptions += "\nThis workflow always reruns unless force_rerun is explicitly false.\n"
 	}
-	paramDescriptions += strings.Join(paramExamples, ",\n")
-	paramDescriptions += "\n  },\n  \"force_rerun\": false\n}\n```"
+
+	// Add example usage
+	paramDescriptions += "\n**Example Usage:**\n```json\n" + buildExampleUsage(workflow) + "\n```"
 
 	// Create complete extended purpose description
 	extendedPurpose := workflow.Purpose + paramDescriptions
 
+	// Catch bad config at startup rather than on the first call
+	if workflow.TaskQueue == "" && cfg.Temporal.DefaultTaskQueue == "" {
+		return fmt.Errorf("%w: set the workflow's taskQueue or temporal.defaultTaskQueue", errNoTaskQueue)
+	}
+	if _, err := newResultRedactor(cfg, workflow); err != nil {
+		return err
+	}
+	if err := validateParamTransforms(workflow.Input); err != nil {
+		return err
+	}
+	if _, ok := resultFormatters[workflow.OutputFormat]; !ok {
+		return fmt.Errorf("unknown outputFormat %q", workflow.OutputFormat)
+	}
+	if workflow.Priority < 0 || workflow.Priority > config.MaxPriority {
+		return fmt.Errorf("priority %d is out of range: must be between 1 (highest) and %d, or 0 for the default",
+			workflow.Priority, config.MaxPriority)
+	}
+
 	// Register the tool with MCP server
-	return server.RegisterTool(name, extendedPurpose, func(args WorkflowParams) (*mcp.ToolResponse, error) {
+	return registerTool(server, cfg, name, extendedPurpose, workflowToolHandler(name, workflow, tempClient, cfg))
+}
+
+// envReference matches a ${NAME} environment variable reference in a param default
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnvDefault resolves ${NAME} references in a param default from the environment at call time, so
+// environment-wide values (a tenant ID, an API base URL) needn't be repeated by every caller. Unset variables expand
+// to "", which the required-params check then reports as missing.
+func expandEnvDefault(param string, value string) string {
+	return expandEnvReferences("the default of param "+param, value)
+}
+
+// expandEnvReferences resolves ${NAME} references in value, warning about unset variables as referenced by source
+func expandEnvReferences(source string, value string) string {
+	return envReference.ReplaceAllStringFunc(value, func(ref string) string {
+		name := envReference.FindStringSubmatch(ref)[1]
+		resolved, ok := os.LookupEnv(name)
+		if !ok {
+			log.Printf("Warning: environment variable %s referenced by %s is not set", name, source)
+		}
+		return resolved
+	})
+}
+
+// resolveNamespace picks the namespace at startup: the --namespace flag wins, then temporal.namespace from the config
+// (whose ${NAME} references are read from the environment, e.g. a per-pod variable), then Temporal's default namespace
+func resolveNamespace(flagValue string, configured string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if namespace := expandEnvReferences("temporal.namespace", configured); namespace != "" {
+		return namespace
+	}
+	return client.DefaultNamespace
+}
+
+// declaredParams returns the sorted names of the params declared in the input's schema or, without one, its fields
+func declaredParams(input config.ParameterDef) []string {
+	if len(input.Schema) > 0 {
+		return inputSchemaProperties(input.Schema)
+	}
+	var names []string
+	for _, field := range input.Fields {
+		names = append(names, slices.Collect(maps.Keys(field))...)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// undeclaredParams returns the sorted names of params that aren't declared in the input's schema or fields
+func undeclaredParams(input config.ParameterDef, params map[string]string) []string {
+	declared := declaredParams(input)
+	var unexpected []string
+	for _, param := range slices.Sorted(maps.Keys(params)) {
+		if _, found := slices.BinarySearch(declared, param); !found {
+			unexpected = append(unexpected, param)
+		}
+	}
+	return unexpected
+}
+
+// workflowToolHandler returns the handler that validates params for, executes, and awaits the given workflow
+func workflowToolHandler(name string, workflow config.WorkflowDef, tempClient client.Client, cfg *config.Config) func(args WorkflowParams) (*mcp.ToolResponse, error) {
+	// LoadConfig rejects invalid durations and retry policies, so they are parsed once here rather than on every call
+	var workerWait, maxTimeout time.Duration
+	if cfg != nil && cfg.WorkerWaitTimeout != "" {
+		workerWait, _ = time.ParseDuration(cfg.WorkerWaitTimeout)
+	}
+	if cfg != nil && cfg.MaxWorkflowTimeout != "" {
+		maxTimeout, _ = time.ParseDuration(cfg.MaxWorkflowTimeout)
+	}
+	retryPolicy, _ := workflowRetryPolicy(workflow.RetryPolicy)
+	idOptions := newWorkflowIDOptions(cfg)
+
+	return func(args WorkflowParams) (*mcp.ToolResponse, error) {
 		// Check if Temporal client is available
 		if tempClient == nil {
 			log.Printf("Error: Temporal client is not available for workflow: %s", name)
-			return mcp.NewToolResponse(mcp.NewTextContent(
-				"Error: Temporal service is currently unavailable. Please try again later.",
-			)), nil
+			return degradedResponse("Error: Temporal service is currently unavailable. Please try again later.")
+		}
+
+		// In strict mode, reject params the workflow doesn't declare (checked before defaults are filled in). Discovered
+		// workflows declare none, so everything they're passed is let through.
+		if cfg != nil && cfg.StrictParams && !workflow.Discovered {
+			if unexpected := undeclaredParams(workflow.Input, args.Params); len(unexpected) > 0 {
+				return paramErrorResponse(
+					withHint(fmt.Sprintf("Error: Unexpected parameters for workflow %s: %s (valid parameters: %s)",
+						name, strings.Join(unexpected, ", "), strings.Join(declaredParams(workflow.Input), ", ")),
+						workflow.ErrorHints.MissingParams),
+					unexpectedParamErrors(unexpected),
+				)
+			}
+		}
+
+		// Fill in omitted parameters from the workflow's defaults (and the active profile's overrides)
+		activeProfile := ""
+		if cfg != nil {
+			activeProfile = cfg.ActiveProfile
+		}
+		defaults := workflow.EffectiveDefaults(activeProfile)
+		if args.Params == nil && len(defaults) > 0 {
+			args.Params = make(map[string]string, len(defaults))
+		}
+		for param, value := range defaults {
+			if args.Params[param] == "" {
+				args.Params[param] = expandEnvDefault(param, value)
+			}
+		}
+
+		// Normalize params, so validation, the workflow ID and the workflow itself all see the same values
+		if err := transformParams(workflow.Input, args.Params); err != nil {
+			return paramErrorResponse(
+				withHint(fmt.Sprintf("Error: Invalid parameters for workflow %s: %v", name, err), workflow.ErrorHints.MissingParams),
+				transformParamErrors(err),
+			)
 		}
 
 		// Validate required parameters before execution
 		if args.Params == nil {
-			return mcp.NewToolResponse(mcp.NewTextContent(
-				fmt.Sprintf("Error: No parameters provided for workflow %s. Please provide required parameters.", name),
-			)), nil
+			return errorResponse(
+				withHint(fmt.Sprintf("Error: No parameters provided for workflow %s. Please provide required parameters.", name),
+					workflow.ErrorHints.MissingParams),
+			)
+		}
+
+		// An input schema supersedes the field list's required checks, and the workflow receives the params as the
+		// schema types them rather than as strings
+		var workflowInput any = args.Params
+		var typedInput map[string]interface{}
+		if len(workflow.Input.Schema) > 0 {
+			typed, violations := validateInputSchema(workflow.Input.Schema, args.Params)
+			workflowInput, typedInput = typed, typed
+			if len(violations) > 0 {
+				return paramErrorResponse(
+					withHint(fmt.Sprintf("Error: Invalid parameters for workflow %s: %s", name, joinViolations(violations)),
+						workflow.ErrorHints.MissingParams),
+					schemaParamErrors(violations),
+				)
+			}
 		}
 
 		// Build list of required parameters
 		var requiredParams []string
-		for _, field := range workflow.Input.Fields {
-			for fieldName, description := range field {
-				if !strings.Contains(description, "Optional") {
-					requiredParams = append(requiredParams, fieldName)
+		if len(workflow.Input.Schema) == 0 {
+			for _, field := range workflow.Input.Fields {
+				for _, fieldName := range slices.Sorted(maps.Keys(field)) {
+					description := field[fieldName]
+					if !strings.Contains(description, "Optional") {
+						requiredParams = append(requiredParams, fieldName)
+					}
 				}
 			}
 		}
 
-		// Check for missing required parameters
-		var missingParams []string
-		for _, param := range requiredParams {
-			if _, exists := args.Params[param]; !exists || args.Params[param] == "" {
-				missingParams = append(missingParams, param)
+		// Check for missing required parameters, itemizing each one for programmatic clients
+		if missing := requiredParamErrors(requiredParams, args.Params); len(missing) > 0 {
+			missingParams := make([]string, 0, len(missing))
+			for _, paramErr := range missing {
+				missingParams = append(missingParams, paramErr.Param)
 			}
-		}
-
-		// Return error if any required parameters are missing
-		if len(missingParams) > 0 {
 			missingParamsList := strings.Join(missingParams, ", ")
-			return mcp.NewToolResponse(mcp.NewTextContent(
-				fmt.Sprintf("Error: Missing required parameters for workflow %s: %s", name, missingParamsList),
-			)), nil
+			return paramErrorResponse(
+				withHint(fmt.Sprintf("Error: Missing required parameters for workflow %s: %s", name, missingParamsList),
+					workflow.ErrorHints.MissingParams),
+				missing,
+			)
 		}
 
 		// Execute the workflow
@@ -214,18 +661,29 @@ func registerWorkflowTool(server *mcp.Server, name string, workflow config.Workf
 			taskQueue = cfg.Temporal.DefaultTaskQueue
 			log.Printf("Using default task queue: %s for workflow %s", taskQueue, name)
 		}
+		if routed, ok := routeTaskQueue(workflow.TaskQueueRouting, args.Params); ok {
+			log.Printf("Routing workflow %s to task queue %s (%s=%s)", name, routed, workflow.TaskQueueRouting.Param,
+				args.Params[workflow.TaskQueueRouting.Param])
+			taskQueue = routed
+		}
 
-		workflowID, err := computeWorkflowID(workflow, args.Params)
+		workflowID, err := computeWorkflowID(workflow, args.Params, typedInput, idOptions)
 		if err != nil {
 			log.Printf("Error computing workflow ID from arguments: %v", err)
-			return mcp.NewToolResponse(mcp.NewTextContent(
+			return errorResponse(
 				fmt.Sprintf("Error computing workflow ID from arguments: %v", err),
-			)), nil
+			)
 		}
 
 		if workflowID == "" {
-			log.Printf("Workflow %q has an empty or missing workflowIDRecipe - using a random workflow id", name)
-			workflowID = uuid.NewString()
+			workflowID, err = fallbackWorkflowID(name, args.Params, idOptions)
+			if err != nil {
+				log.Printf("Error computing fallback workflow ID: %v", err)
+				return errorResponse(
+					fmt.Sprintf("Error computing workflow ID from arguments: %v", err),
+				)
+			}
+			log.Printf("Workflow %q has an empty or missing workflowIDRecipe - using workflow id %s", name, workflowID)
 		}
 
 		// This will execute a new workflow when:
@@ -239,7 +697,11 @@ func registerWorkflowTool(server *mcp.Server, name string, workflow config.Workf
 		reusePolicy := temporal_enums.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE_FAILED_ONLY
 		conflictPolicy := temporal_enums.WORKFLOW_ID_CONFLICT_POLICY_USE_EXISTING
 
-		if args.ForceRerun {
+		forceRerun := workflow.DefaultForceRerun
+		if args.ForceRerun != nil {
+			forceRerun = *args.ForceRerun
+		}
+		if forceRerun {
 			// This will execute a new workflow in all cases. If there is a running workflow with the given id, it will
 			// be terminated.
 			reusePolicy = temporal_enums.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE
@@ -251,184 +713,343 @@ func registerWorkflowTool(server *mcp.Server, name string, workflow config.Workf
 			ID:                       workflowID,
 			WorkflowIDReusePolicy:    reusePolicy,
 			WorkflowIDConflictPolicy: conflictPolicy,
+			Priority:                 temporal_sdk.Priority{PriorityKey: workflow.Priority},
+		}
+
+		// Warnings are returned alongside the result
+		var warnings []string
+
+		timeouts, err := callerWorkflowTimeouts(args, maxTimeout)
+		if err != nil {
+			return errorResponse(
+				fmt.Sprintf("Error: Invalid timeout for workflow %s: %v", name, err),
+			)
+		}
+		if err := applyConfiguredTimeouts(&timeouts, workflow); err != nil {
+			return errorResponse(
+				fmt.Sprintf("Error: Invalid configuration for workflow %s: %v", name, err),
+			)
+		}
+		wfOptions.WorkflowRunTimeout = timeouts.run
+		wfOptions.WorkflowExecutionTimeout = timeouts.execution
+		warnings = append(warnings, timeouts.warnings...)
+		wfOptions.RetryPolicy = retryPolicy
+
+		// Without a worker polling the task queue the workflow won't make progress. With a worker wait timeout, give a
+		// worker that long to come up and otherwise fail fast. Without one, still start it but say why it might hang.
+		if workerWait > 0 {
+			ctx, cancel := context.WithTimeout(context.Background(), workerWait)
+			available := waitForWorker(ctx, tempClient, taskQueue)
+			cancel()
+			if !available {
+				log.Printf("No worker polled task queue %s within %s - not starting workflow %s", taskQueue, workerWait, name)
+				return errorResponse(withHint(fmt.Sprintf(
+					"Error: no worker available - no worker polled task queue %s within %s, so workflow %s was not started",
+					taskQueue, workerWait, name), workflow.ErrorHints.Failure))
+			}
+		} else if cfg != nil && cfg.CheckTaskQueuePollers {
+			ctx, cancel := context.WithTimeout(context.Background(), taskQueueCheckTimeout)
+			if warning := taskQueuePollerWarning(ctx, tempClient, taskQueue); warning != "" {
+				log.Print(warning)
+				warnings = append(warnings, warning)
+			}
+			cancel()
 		}
 
 		log.Printf("Starting workflow %s on task queue %s", name, taskQueue)
+		logWorkflowParams(cfg, name, args.Params)
 
 		// Start workflow execution
-		run, err := tempClient.ExecuteWorkflow(context.Background(), wfOptions, name, args.Params)
+		run, err := tempClient.ExecuteWorkflow(context.Background(), wfOptions, name, workflowInput)
 		if err != nil {
 			log.Printf("Error starting workflow %s: %v", name, err)
-			return mcp.NewToolResponse(mcp.NewTextContent(
-				fmt.Sprintf("Error executing workflow: %v", err),
-			)), nil
+			return errorResponse(
+				withHint(fmt.Sprintf("Error executing workflow: %v", err), workflow.ErrorHints.Failure),
+			)
 		}
 
 		log.Printf("Workflow started: WorkflowID=%s RunID=%s", run.GetID(), run.GetRunID())
 
-		// Wait for workflow completion
-		var result string
-		if err := run.Get(context.Background(), &result); err != nil {
+		// With a UI configured, every response after the start links to the run, so humans can jump straight to it
+		var uiLink []*mcp.Content
+		uiCfg := cfg
+		if cfg != nil && workflow.Namespace != "" {
+			namespaced := *cfg
+			namespaced.Temporal.Namespace = workflow.Namespace
+			uiCfg = &namespaced
+		}
+		if link := workflowUIURL(uiCfg, run.GetID(), run.GetRunID()); link != "" {
+			uiLink = append(uiLink, mcp.NewTextContent("Temporal UI: "+link))
+		}
+
+		// Async calls don't wait; the caller follows up using the IDs
+		if args.Async {
+			started := startedWorkflow{WorkflowID: run.GetID(), RunID: run.GetRunID()}
+			if uiCfg != nil && temporalNamespace(uiCfg) != temporalNamespace(cfg) {
+				started.Namespace = temporalNamespace(uiCfg)
+			}
+			bytes, err := json.Marshal(started)
+			if err != nil {
+				return nil, err
+			}
+			contents := []*mcp.Content{mcp.NewTextContent(string(bytes))}
+			for _, warning := range warnings {
+				contents = append(contents, mcp.NewTextContent(warning))
+			}
+			return mcp.NewToolResponse(append(contents, uiLink...)...), nil
+		}
+
+		// Wait for workflow completion. Decoding into an interface{} accepts any result type: strings are returned as
+		// they are, everything else as JSON. With an execution timeout, stop waiting once it has passed, so a stuck
+		// workflow can't hang the tool call.
+		getCtx := context.Background()
+		if timeouts.execution > 0 {
+			var cancel context.CancelFunc
+			getCtx, cancel = context.WithTimeout(getCtx, timeouts.execution)
+			defer cancel()
+		}
+		var result interface{}
+		if err := run.Get(getCtx, &result); err != nil {
+			if errors.Is(getCtx.Err(), context.DeadlineExceeded) {
+				msg := fmt.Sprintf("Error: workflow %s (WorkflowID=%s RunID=%s) did not complete within %s",
+					name, run.GetID(), run.GetRunID(), timeouts.execution)
+				log.Print(msg)
+				return errorResponse(withHint(msg, workflow.ErrorHints.Failure), uiLink...)
+			}
 			log.Printf("Error in workflow %s execution: %v", name, err)
-			return mcp.NewToolResponse(mcp.NewTextContent(
-				fmt.Sprintf("Workflow failed: %v", err),
-			)), nil
+			var notes []*mcp.Content
+			if workflow.FailureQuery != "" {
+				notes = append(notes, mcp.NewTextContent(partialStateText(tempClient, run, workflow.FailureQuery)))
+			}
+			return errorResponse(withHint(fmt.Sprintf("Workflow failed: %v", err), workflow.ErrorHints.Failure), append(notes, uiLink...)...)
 		}
 
 		log.Printf("Workflow %s completed successfully", name)
 
-		return mcp.NewToolResponse(mcp.NewTextContent(result)), nil
-	})
+		// A result that doesn't match the declared output schema usually means worker/config drift. Still return it,
+		// but flag the mismatch.
+		if len(workflow.Output.Schema) > 0 {
+			if violations := schema.Validate(workflow.Output.Schema, result); len(violations) > 0 {
+				warning := fmt.Sprintf("Warning: the result of workflow %s does not match its declared output schema: %s",
+					name, joinViolations(violations))
+				log.Print(warning)
+				warnings = append(warnings, warning)
+			}
+		}
+
+		// Redact after the schema check, so masked values aren't reported as mismatches
+		redactor, err := newResultRedactor(cfg, workflow)
+		if err != nil {
+			return nil, err
+		}
+		result = redactor.redact(result)
+
+		if workflow.ResultField != "" {
+			result, err = extractResultField(result, workflow.ResultField)
+			if err != nil {
+				log.Printf("Error extracting resultField of workflow %s: %v", name, err)
+				return errorResponse(fmt.Sprintf(
+					"Error: workflow %s (WorkflowID=%s RunID=%s) completed, but its %v", name, run.GetID(), run.GetRunID(), err,
+				), uiLink...)
+			}
+		}
+
+		resultText, err := formatWorkflowResult(workflow.OutputFormat, result)
+		if err != nil {
+			log.Printf("Error formatting the result of workflow %s: %v", name, err)
+			return errorResponse(fmt.Sprintf(
+				"Error: workflow %s (WorkflowID=%s RunID=%s) completed, but its result could not be formatted: %v",
+				name, run.GetID(), run.GetRunID(), err,
+			), uiLink...)
+		}
+
+		// An object or array too large to return falls back to a summary of its shape rather than being cut mid-value
+		if exceedsResponseLimit(cfg, append([]string{resultText}, warnings...)...) {
+			if summary, ok := summarizeResult(result, len(resultText)); ok {
+				bytes, err := json.Marshal(summary)
+				if err != nil {
+					return nil, err
+				}
+				warnings = append(warnings, fmt.Sprintf("Note: the result of workflow %s is %d bytes, over the %d byte "+
+					"maxToolResponseBytes limit, so a summary of it is returned instead", name, len(resultText),
+					cfg.MaxToolResponseBytes))
+				resultText = string(bytes)
+			}
+		}
+
+		contents := []*mcp.Content{mcp.NewTextContent(resultText)}
+		for _, warning := range warnings {
+			contents = append(contents, mcp.NewTextContent(warning))
+		}
+		return mcp.NewToolResponse(append(contents, uiLink...)...), nil
+	}
 }
 
-func computeWorkflowID(workflow config.WorkflowDef, params map[string]string) (string, error) {
-	tmpl := template.New("id_recipe")
+// partialStateText asks a failed run for the partial state it recorded, via the workflow's failureQuery, and renders it.
+// A query that also fails is reported rather than returned as an error, so the workflow's own failure isn't hidden.
+func partialStateText(tempClient client.Client, run client.WorkflowRun, queryType string) string {
+	value, err := tempClient.QueryWorkflow(context.Background(), run.GetID(), run.GetRunID(), queryType)
+	if err != nil {
+		log.Printf("Error querying partial state of failed workflow %s: %v", run.GetID(), err)
+		return fmt.Sprintf("Partial state unavailable: query %s failed: %v", queryType, err)
+	}
 
-	tmpl.Funcs(template.FuncMap{
-		"hash": func(paramsToHash ...any) (string, error) {
-			return hashWorkflowArgs(params, paramsToHash...)
-		},
-	})
-	if _, err := tmpl.Parse(workflow.WorkflowIDRecipe); err != nil {
-		return "", err
+	var state interface{}
+	if err := value.Get(&state); err != nil {
+		return fmt.Sprintf("Partial state unavailable: could not decode the result of query %s: %v", queryType, err)
 	}
+	text, err := workflowResultText(state)
+	if err != nil {
+		return fmt.Sprintf("Partial state unavailable: could not render the result of query %s: %v", queryType, err)
+	}
+	return fmt.Sprintf("Partial state (from query %s):\n%s", queryType, text)
+}
 
-	writer := strings.Builder{}
-	if err := tmpl.Execute(&writer, params); err != nil {
-		return "", err
+// withHint appends a workflow's configured error hint, if any, to an error message
+func withHint(msg, hint string) string {
+	if hint == "" {
+		return msg
 	}
+	return msg + "\n" + hint
+}
 
-	return writer.String(), nil
+// workflowUIURL returns the Temporal Web UI page of a workflow run, or "" when no UI base URL is configured. cfg may be
+// nil.
+func workflowUIURL(cfg *config.Config, workflowID, runID string) string {
+	if cfg == nil || cfg.Temporal.UIBaseURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/namespaces/%s/workflows/%s/%s/history", strings.TrimRight(cfg.Temporal.UIBaseURL, "/"),
+		url.PathEscape(temporalNamespace(cfg)), url.PathEscape(workflowID), url.PathEscape(runID))
 }
 
-// registerGetWorkflowHistoryTool registres a tool that gets workflow histories
-func registerGetWorkflowHistoryTool(server *mcp.Server, tempClient client.Client) error {
-	type GetWorkflowHistoryParams struct {
-		WorkflowID string `json:"workflowId"`
-		RunID      string `json:"runId"`
+// temporalNamespace returns the configured namespace, or the SDK's default namespace when none is configured
+func temporalNamespace(cfg *config.Config) string {
+	if cfg == nil || cfg.Temporal.Namespace == "" {
+		return client.DefaultNamespace
 	}
-	desc := "Gets the workflow execution history for a specific run of a workflow. runId is optional - if omitted, this tool gets the history for the latest run of the given workflowId"
+	return cfg.Temporal.Namespace
+}
 
-	return server.RegisterTool("GetWorkflowHistory", desc, func(args GetWorkflowHistoryParams) (*mcp.ToolResponse, error) {
-		// Check if Temporal client is available
-		if tempClient == nil {
-			log.Printf("Error: Temporal client is not available for getting workflow histories")
-			return mcp.NewToolResponse(mcp.NewTextContent(
-				"Error: Temporal client is not available for getting workflow histories",
-			)), nil
-		}
+// workflowResultText renders a decoded workflow result for a tool response: strings as-is, anything else as JSON
+func workflowResultText(result interface{}) (string, error) {
+	if str, ok := result.(string); ok {
+		return str, nil
+	}
+	bytes, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
 
-		eventJsons := make([]string, 0)
-		iterator := tempClient.GetWorkflowHistory(context.Background(), args.WorkflowID, args.RunID, false, temporal_enums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
-		for iterator.HasNext() {
-			event, err := iterator.Next()
-			if err != nil {
-				msg := fmt.Sprintf("Error: Failed to get %dth history event: %v", len(eventJsons), err)
-				log.Print(msg)
-				return mcp.NewToolResponse(mcp.NewTextContent(msg)), nil
-			}
+// registerSystemPrompt registers the system prompt for the MCP
+func registerSystemPrompt(server *mcp.Server, cfg *config.Config) error {
+	return server.RegisterPrompt("system_prompt", "System prompt for the Temporal MCP", func(_ struct{}) (*mcp.PromptResponse, error) {
+		systemPrompt := buildSystemPrompt(cfg)
+		return mcp.NewPromptResponse("system_prompt", mcp.NewPromptMessage(mcp.NewTextContent(systemPrompt), mcp.Role("system"))), nil
+	})
+}
 
-			sanitize_history_event.SanitizeHistoryEvent(event)
-			bytes, err := protojson.Marshal(event)
-			if err != nil {
-				// should never happen?
-				return nil, err
-			}
+// buildSystemPrompt renders the system prompt describing the configured workflows. With many workflows the verbose
+// per-workflow blocks can blow the client's context window, so cfg.SystemPromptStyle can switch to a compact index and
+// cfg.SystemPromptMaxWorkflows can cap how many workflows are described.
+func buildSystemPrompt(cfg *config.Config) string {
+	names := make([]string, 0, len(cfg.Workflows))
+	for name := range cfg.Workflows {
+		names = append(names, name)
+	}
+	// Sort so that capping the list is deterministic
+	sort.Strings(names)
 
-			eventJsons = append(eventJsons, string(bytes))
+	omitted := 0
+	if cfg.SystemPromptMaxWorkflows > 0 && len(names) > cfg.SystemPromptMaxWorkflows {
+		omitted = len(names) - cfg.SystemPromptMaxWorkflows
+		names = names[:cfg.SystemPromptMaxWorkflows]
+	}
+	compact := cfg.SystemPromptStyle == config.SystemPromptStyleCompact
+
+	// Build list of available tools from workflows
+	workflowList := ""
+	for _, name := range names {
+		workflow := cfg.Workflows[name]
+		if compact {
+			workflowList += fmt.Sprintf("- `%s`: %s\n", toolName(cfg, name), firstLine(workflow.Purpose))
+			continue
 		}
 
-		// The last step of json-marshalling is unfortunate (forced on us by the lack of a proto for the list of
-		// events), but not worth actually building and marshalling a slice for. Let's just do it by hand.
-		allEvents := strings.Builder{}
-		allEvents.WriteString("[")
-		for i, eventJson := range eventJsons {
-			if i > 0 {
-				allEvents.WriteString(",")
-			}
-			allEvents.WriteString(eventJson)
-		}
-		allEvents.WriteString("]")
+		// Use the complete purpose which already includes parameter details from config.yml
+		detailedPurpose := workflow.Purpose
 
-		return mcp.NewToolResponse(mcp.NewTextContent(allEvents.String())), nil
-	})
-}
+		workflowList += fmt.Sprintf("## %s\n", toolName(cfg, name))
+		workflowList += fmt.Sprintf("**Purpose:** %s\n\n", detailedPurpose)
+		if workflow.Discovered {
+			// Nothing is known about a discovered workflow's input or output beyond its purpose
+			workflowList += "---\n\n"
+			continue
+		}
+		workflowList += fmt.Sprintf("**Input Type:** %s\n\n", workflow.Input.Type)
 
-// registerSystemPrompt registers the system prompt for the MCP
-func registerSystemPrompt(server *mcp.Server, cfg *config.Config) error {
-	return server.RegisterPrompt("system_prompt", "System prompt for the Temporal MCP", func(_ struct{}) (*mcp.PromptResponse, error) {
-		// Build list of available tools from workflows
-		workflowList := ""
-		for name, workflow := range cfg.Workflows {
-			// Use the complete purpose which already includes parameter details from config.yml
-			detailedPurpose := workflow.Purpose
-
-			workflowList += fmt.Sprintf("## %s\n", name)
-			workflowList += fmt.Sprintf("**Purpose:** %s\n\n", detailedPurpose)
-			workflowList += fmt.Sprintf("**Input Type:** %s\n\n", workflow.Input.Type)
-
-			// Add parameters section with detailed formatting based on the Input.Fields
-			workflowList += "**Parameters:**\n"
-			for _, field := range workflow.Input.Fields {
-				for fieldName, description := range field {
-					isRequired := !strings.Contains(description, "Optional")
-					if isRequired {
-						workflowList += fmt.Sprintf("- `%s` (required): %s\n", fieldName, description)
-					} else {
-						workflowList += fmt.Sprintf("- `%s` (optional): %s\n", fieldName, description)
-					}
+		// Add parameters section with detailed formatting based on the Input.Fields
+		workflowList += "**Parameters:**\n"
+		for _, field := range workflow.Input.Fields {
+			for _, fieldName := range slices.Sorted(maps.Keys(field)) {
+				description := field[fieldName]
+				isRequired := !strings.Contains(description, "Optional")
+				if isRequired {
+					workflowList += fmt.Sprintf("- `%s` (required): %s\n", fieldName, description)
+				} else {
+					workflowList += fmt.Sprintf("- `%s` (optional): %s\n", fieldName, description)
 				}
 			}
+		}
 
-			// Add example of how to call this workflow
-			workflowList += "\n**Example Usage:**\n"
-			workflowList += "```json\n"
-			workflowList += "{\n  \"params\": {\n"
+		if len(workflow.Input.Schema) > 0 {
+			workflowList += "\n" + inputSchemaDescription(workflow.Input.Schema)
+		}
 
-			// Generate example parameters
-			paramExamples := []string{}
-			for _, field := range workflow.Input.Fields {
-				for fieldName, _ := range field {
-					if strings.Contains(fieldName, "json") {
-						paramExamples = append(paramExamples, fmt.Sprintf("    \"%s\": {\"example\": \"value\"}", fieldName))
-					} else if strings.Contains(fieldName, "id") {
-						paramExamples = append(paramExamples, fmt.Sprintf("    \"%s\": \"example-id-123\"", fieldName))
-					} else {
-						paramExamples = append(paramExamples, fmt.Sprintf("    \"%s\": \"example value\"", fieldName))
-					}
-				}
-			}
-			workflowList += strings.Join(paramExamples, ",\n")
-			workflowList += "\n  },\n  \"force_rerun\": false\n}\n```\n"
+		// Add example of how to call this workflow
+		workflowList += "\n**Example Usage:**\n"
+		workflowList += "```json\n"
+		workflowList += buildExampleUsage(workflow)
+		workflowList += "\n```\n"
 
-			// Add output information
-			workflowList += fmt.Sprintf("\n**Output Type:** %s\n", workflow.Output.Type)
-			if workflow.Output.Description != "" {
-				workflowList += fmt.Sprintf("**Output Description:** %s\n", workflow.Output.Description)
-			}
+		// Add output information
+		workflowList += fmt.Sprintf("\n**Output Type:** %s\n", workflow.Output.Type)
+		if workflow.Output.Description != "" {
+			workflowList += fmt.Sprintf("**Output Description:** %s\n", workflow.Output.Description)
+		}
 
-			// Extract required parameters for validation guidance
-			var requiredParams []string
-			for _, field := range workflow.Input.Fields {
-				for fieldName, description := range field {
-					if !strings.Contains(description, "Optional") {
-						requiredParams = append(requiredParams, fieldName)
-					}
+		// Extract required parameters for validation guidance
+		var requiredParams []string
+		for _, field := range workflow.Input.Fields {
+			for _, fieldName := range slices.Sorted(maps.Keys(field)) {
+				description := field[fieldName]
+				if !strings.Contains(description, "Optional") {
+					requiredParams = append(requiredParams, fieldName)
 				}
 			}
+		}
 
-			// Add validation guidelines
-			if len(requiredParams) > 0 {
-				workflowList += "\n**Required Validation:**\n"
-				workflowList += "- Validate all required parameters are provided before execution\n"
-				paramsList := strings.Join(requiredParams, ", ")
-				workflowList += fmt.Sprintf("- Required parameters: %s\n", paramsList)
-			}
-
-			workflowList += "\n---\n\n"
+		// Add validation guidelines
+		if len(requiredParams) > 0 {
+			workflowList += "\n**Required Validation:**\n"
+			workflowList += "- Validate all required parameters are provided before execution\n"
+			paramsList := strings.Join(requiredParams, ", ")
+			workflowList += fmt.Sprintf("- Required parameters: %s\n", paramsList)
 		}
 
-		systemPrompt := fmt.Sprintf(`You are now connected to a Temporal MCP (Model Control Protocol) server that provides access to various Temporal workflows.
+		workflowList += "\n---\n\n"
+	}
+
+	if compact {
+		workflowList += "\nEach tool's description lists its parameters and an example call.\n\n"
+	}
+	if omitted > 0 {
+		workflowList += fmt.Sprintf("_%d more workflows are available but not described here - see the tool list for their descriptions._\n\n", omitted)
+	}
+
+	systemPrompt := fmt.Sprintf(`You are now connected to a Temporal MCP (Model Control Protocol) server that provides access to various Temporal workflows.
 
 This MCP exposes the following workflow tools:
 
@@ -451,6 +1072,8 @@ When constructing your calls:
 - Include all required parameters
 - Set force_rerun to true only when explicitly requested by the user
 - When force_rerun is false, Temporal will deduplicate workflows based on their arguments
+- Set run_timeout or execution_timeout (e.g. "10m") only to bound an expensive or exploratory run
+- Set async to true for long-running workflows: the call returns the workflowId and runId as soon as the workflow has started, and you can check on it later with the workflow history or status tools (pass the namespace too when the response includes one)
 
 ## General Example Structure
 
@@ -467,8 +1090,11 @@ To call any workflow:
 
 Refer to each workflow's specific example above for exact parameter requirements.`, workflowList)
 
-		return mcp.NewPromptResponse("system_prompt", mcp.NewPromptMessage(mcp.NewTextContent(systemPrompt), mcp.Role("system"))), nil
-	})
+	return systemPrompt
 }
 
-
+// firstLine returns the first line of s, for one-line summaries
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
@@ -2,10 +2,22 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"github.com/stretchr/testify/require"
+	"os"
+	"sort"
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 
+	"github.com/google/uuid"
+	mcp "github.com/metoro-io/mcp-golang"
+	mcphttp "github.com/metoro-io/mcp-golang/transport/http"
 	"github.com/mocksi/temporal-mcp/internal/config"
+	temporal_enums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/client"
 )
 
 // TestGetTaskQueue tests the task queue selection logic
@@ -263,9 +275,755 @@ func TestWorkflowIDComputation(t *testing.T) {
 			def := config.WorkflowDef{
 				WorkflowIDRecipe: tc.recipe,
 			}
-			actual, err := computeWorkflowID(def, tc.args)
+			actual, err := computeWorkflowID(def, tc.args, nil, workflowIDOptions{})
 			require.NoError(t, err)
 			require.Equal(t, tc.expected, actual)
 		})
 	}
 }
+
+func TestWorkflowIDSanitization(t *testing.T) {
+	def := config.WorkflowDef{
+		WorkflowIDRecipe: "report_{{ .path }}_{{ .note }}",
+	}
+
+	t.Run("slashes and newlines are replaced", func(t *testing.T) {
+		actual, err := computeWorkflowID(def, map[string]string{"path": "a/b\\c", "note": "line1\nline2\tend"}, nil, workflowIDOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "report_a_b_c_line1_line2_end", actual)
+	})
+
+	t.Run("template-like values are not evaluated", func(t *testing.T) {
+		actual, err := computeWorkflowID(def, map[string]string{"path": "{{.note}}", "note": "x"}, nil, workflowIDOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "report_{{.note}}_x", actual)
+	})
+
+	t.Run("very long values are bounded", func(t *testing.T) {
+		actual, err := computeWorkflowID(def, map[string]string{"path": strings.Repeat("é", 2000), "note": "x"}, nil, workflowIDOptions{})
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(actual), maxWorkflowIDLength)
+		require.True(t, utf8.ValidString(actual))
+		require.True(t, strings.HasPrefix(actual, "report_é"))
+	})
+
+	t.Run("hash uses the original values", func(t *testing.T) {
+		hashDef := config.WorkflowDef{WorkflowIDRecipe: "id_{{ hash .path }}"}
+		escaped, err := computeWorkflowID(hashDef, map[string]string{"path": "a/b"}, nil, workflowIDOptions{})
+		require.NoError(t, err)
+		plain, err := computeWorkflowID(hashDef, map[string]string{"path": "a_b"}, nil, workflowIDOptions{})
+		require.NoError(t, err)
+		require.NotEqual(t, plain, escaped)
+	})
+}
+
+func TestSystemPromptStyles(t *testing.T) {
+	cfg := &config.Config{
+		Workflows: map[string]config.WorkflowDef{
+			"AlphaWorkflow": {
+				Purpose: "Does alpha things.\nWith a second line of detail.",
+				Input: config.ParameterDef{
+					Type:   "AlphaRequest",
+					Fields: []map[string]string{{"alpha_id": "The alpha ID"}},
+				},
+			},
+			"BetaWorkflow": {
+				Purpose: "Does beta things.",
+				Input: config.ParameterDef{
+					Type:   "BetaRequest",
+					Fields: []map[string]string{{"beta_id": "The beta ID"}},
+				},
+			},
+		},
+	}
+
+	t.Run("verbose by default", func(t *testing.T) {
+		prompt := buildSystemPrompt(cfg)
+		require.Contains(t, prompt, "## AlphaWorkflow")
+		require.Contains(t, prompt, "**Example Usage:**")
+		require.Contains(t, prompt, `"alpha_id": "example-id-123"`)
+	})
+
+	t.Run("compact omits example blocks", func(t *testing.T) {
+		compactCfg := *cfg
+		compactCfg.SystemPromptStyle = config.SystemPromptStyleCompact
+		prompt := buildSystemPrompt(&compactCfg)
+		require.Contains(t, prompt, "- `AlphaWorkflow`: Does alpha things.\n")
+		require.Contains(t, prompt, "- `BetaWorkflow`: Does beta things.\n")
+		require.NotContains(t, prompt, "**Example Usage:**")
+		require.NotContains(t, prompt, "With a second line of detail.")
+		require.NotContains(t, prompt, `"alpha_id"`)
+	})
+
+	t.Run("max workflows caps the list", func(t *testing.T) {
+		cappedCfg := *cfg
+		cappedCfg.SystemPromptMaxWorkflows = 1
+		prompt := buildSystemPrompt(&cappedCfg)
+		require.Contains(t, prompt, "## AlphaWorkflow")
+		require.NotContains(t, prompt, "## BetaWorkflow")
+		require.Contains(t, prompt, "1 more workflows are available")
+	})
+}
+
+func TestWorkflowIDHashSalt(t *testing.T) {
+	def := config.WorkflowDef{WorkflowIDRecipe: "id_{{ hash . }}"}
+	params := map[string]string{"one": "1", "two": "2"}
+
+	unsalted, err := computeWorkflowID(def, params, nil, workflowIDOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "id_3822076040", unsalted, "an empty salt must keep existing IDs stable")
+
+	tenantA, err := computeWorkflowID(def, params, nil, workflowIDOptions{HashSalt: "tenant-a"})
+	require.NoError(t, err)
+	tenantAAgain, err := computeWorkflowID(def, params, nil, workflowIDOptions{HashSalt: "tenant-a"})
+	require.NoError(t, err)
+	tenantB, err := computeWorkflowID(def, params, nil, workflowIDOptions{HashSalt: "tenant-b"})
+	require.NoError(t, err)
+
+	require.Equal(t, tenantA, tenantAAgain)
+	require.NotEqual(t, tenantA, tenantB)
+	require.NotEqual(t, unsalted, tenantA)
+}
+
+// testWorkflow returns a workflow definition with one required field
+func testWorkflow() config.WorkflowDef {
+	return config.WorkflowDef{
+		Purpose:          "Looks up an order",
+		WorkflowIDRecipe: "order_{{ .order_id }}",
+		TaskQueue:        "orders",
+		Input: config.ParameterDef{
+			Type:   "OrderRequest",
+			Fields: []map[string]string{{"order_id": "The order ID"}},
+		},
+	}
+}
+
+func TestWorkflowToolResults(t *testing.T) {
+	args := WorkflowParams{Params: map[string]string{"order_id": "42"}}
+
+	t.Run("string result is returned as-is", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runResult: "order 42 shipped"}
+		resp, err := workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, &config.Config{})(args)
+		require.NoError(t, err)
+		require.Len(t, resp.Content, 1)
+		require.Equal(t, "order 42 shipped", resp.Content[0].TextContent.Text)
+		require.Equal(t, "order_42", mockClient.lastStartOptions.ID)
+		require.Equal(t, "orders", mockClient.lastStartOptions.TaskQueue)
+	})
+
+	t.Run("struct result is returned as JSON", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runResult: map[string]any{"status": "shipped"}}
+		resp, err := workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, &config.Config{})(args)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"status": "shipped"}`, resp.Content[0].TextContent.Text)
+	})
+}
+
+func TestWorkflowToolResultField(t *testing.T) {
+	type item struct {
+		SKU string `json:"sku"`
+	}
+	type shipment struct {
+		Status string `json:"status"`
+		Items  []item `json:"items"`
+	}
+	type orderResult struct {
+		OrderID  string   `json:"orderId"`
+		Shipment shipment `json:"shipment"`
+	}
+	mockClient := &mockTemporalClient{runResult: orderResult{
+		OrderID:  "42",
+		Shipment: shipment{Status: "in transit", Items: []item{{SKU: "abc"}, {SKU: "def"}}},
+	}}
+	args := WorkflowParams{Params: map[string]string{"order_id": "42"}}
+
+	t.Run("nested field", func(t *testing.T) {
+		workflow := testWorkflow()
+		workflow.ResultField = "shipment.status"
+		resp, err := workflowToolHandler("OrderWorkflow", workflow, mockClient, &config.Config{})(args)
+		require.NoError(t, err)
+		require.Len(t, resp.Content, 1)
+		require.Equal(t, "in transit", resp.Content[0].TextContent.Text)
+	})
+
+	t.Run("array element and object field", func(t *testing.T) {
+		workflow := testWorkflow()
+		workflow.ResultField = "shipment.items.1"
+		resp, err := workflowToolHandler("OrderWorkflow", workflow, mockClient, &config.Config{})(args)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"sku": "def"}`, resp.Content[0].TextContent.Text)
+	})
+
+	t.Run("missing path", func(t *testing.T) {
+		workflow := testWorkflow()
+		workflow.ResultField = "shipment.carrier.name"
+		resp, err := asResponse(workflowToolHandler("OrderWorkflow", workflow, mockClient, &config.Config{})(args))
+		require.NoError(t, err)
+		text := resp.Content[0].TextContent.Text
+		require.True(t, strings.HasPrefix(text, "Error: workflow OrderWorkflow"), text)
+		require.Contains(t, text, `result has no field "shipment.carrier"`)
+	})
+
+	t.Run("path through a scalar", func(t *testing.T) {
+		workflow := testWorkflow()
+		workflow.ResultField = "orderId.value"
+		resp, err := asResponse(workflowToolHandler("OrderWorkflow", workflow, mockClient, &config.Config{})(args))
+		require.NoError(t, err)
+		require.Contains(t, resp.Content[0].TextContent.Text, `"orderId" is not an object or array`)
+	})
+}
+
+func TestWorkflowConfiguredStartOptions(t *testing.T) {
+	args := WorkflowParams{Params: map[string]string{"order_id": "42"}}
+
+	workflow := testWorkflow()
+	workflow.RunTimeout = "10m"
+	workflow.RetryPolicy = &config.RetryOptions{InitialInterval: "1s", MaximumAttempts: 3}
+	mockClient := &mockTemporalClient{runResult: "ok"}
+	_, err := workflowToolHandler("OrderWorkflow", workflow, mockClient, &config.Config{})(args)
+	require.NoError(t, err)
+	require.Equal(t, 10*time.Minute, mockClient.lastStartOptions.WorkflowRunTimeout)
+	require.NotNil(t, mockClient.lastStartOptions.RetryPolicy)
+	require.Equal(t, time.Second, mockClient.lastStartOptions.RetryPolicy.InitialInterval)
+	require.Equal(t, int32(3), mockClient.lastStartOptions.RetryPolicy.MaximumAttempts)
+
+	// A caller's timeout wins over the configured one
+	args.RunTimeout = "5m"
+	_, err = workflowToolHandler("OrderWorkflow", workflow, mockClient, &config.Config{})(args)
+	require.NoError(t, err)
+	require.Equal(t, 5*time.Minute, mockClient.lastStartOptions.WorkflowRunTimeout)
+
+	mockClient = &mockTemporalClient{runResult: "ok"}
+	_, err = workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, &config.Config{})(WorkflowParams{Params: args.Params})
+	require.NoError(t, err)
+	require.Nil(t, mockClient.lastStartOptions.RetryPolicy, "unset retry policy leaves the server default")
+}
+
+func TestWorkflowPriority(t *testing.T) {
+	args := WorkflowParams{Params: map[string]string{"order_id": "42"}}
+
+	workflow := testWorkflow()
+	workflow.Priority = 1
+	mockClient := &mockTemporalClient{runResult: "ok"}
+	_, err := workflowToolHandler("OrderWorkflow", workflow, mockClient, &config.Config{})(args)
+	require.NoError(t, err)
+	require.Equal(t, 1, mockClient.lastStartOptions.Priority.PriorityKey)
+
+	mockClient = &mockTemporalClient{runResult: "ok"}
+	_, err = workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, &config.Config{})(args)
+	require.NoError(t, err)
+	require.Zero(t, mockClient.lastStartOptions.Priority.PriorityKey, "unset priority leaves the server default")
+
+	for _, priority := range []int{-1, config.MaxPriority + 1} {
+		workflow.Priority = priority
+		server := mcp.NewServer(mcphttp.NewHTTPTransport("/mcp"))
+		err := registerWorkflowTool(server, "OrderWorkflow", workflow, nil, &config.Config{})
+		require.ErrorContains(t, err, "out of range")
+	}
+}
+
+func TestWorkflowUIURL(t *testing.T) {
+	cfg := &config.Config{Temporal: config.TemporalConfig{Namespace: "payments", UIBaseURL: "https://temporal.example.com/"}}
+	require.Equal(t, "https://temporal.example.com/namespaces/payments/workflows/order_42/run-1/history",
+		workflowUIURL(cfg, "order_42", "run-1"))
+	require.Equal(t, "https://temporal.example.com/namespaces/payments/workflows/tenant%2Forder%2042/run-1/history",
+		workflowUIURL(cfg, "tenant/order 42", "run-1"))
+
+	cfg.Temporal.Namespace = ""
+	require.Equal(t, "https://temporal.example.com/namespaces/default/workflows/order_42/run-1/history",
+		workflowUIURL(cfg, "order_42", "run-1"))
+
+	require.Empty(t, workflowUIURL(&config.Config{}, "order_42", "run-1"))
+	require.Empty(t, workflowUIURL(nil, "order_42", "run-1"))
+
+	t.Run("workflow responses link to the run", func(t *testing.T) {
+		args := WorkflowParams{Params: map[string]string{"order_id": "42"}}
+		cfg := &config.Config{Temporal: config.TemporalConfig{Namespace: "payments", UIBaseURL: "http://localhost:8233"}}
+		link := "Temporal UI: http://localhost:8233/namespaces/payments/workflows/order_42/mock-run-id/history"
+
+		resp, err := workflowToolHandler("OrderWorkflow", testWorkflow(), &mockTemporalClient{runResult: "done"}, cfg)(args)
+		require.NoError(t, err)
+		require.Len(t, resp.Content, 2)
+		require.Equal(t, "done", resp.Content[0].TextContent.Text)
+		require.Equal(t, link, resp.Content[1].TextContent.Text)
+
+		resp, err = asResponse(workflowToolHandler("OrderWorkflow", testWorkflow(), &mockTemporalClient{runErr: errors.New("boom")}, cfg)(args))
+		require.NoError(t, err)
+		require.Len(t, resp.Content, 2)
+		require.Equal(t, link, resp.Content[1].TextContent.Text)
+	})
+}
+
+func TestWorkflowToolOutputSchemaWarning(t *testing.T) {
+	workflow := testWorkflow()
+	workflow.Output.Schema = map[string]any{
+		"type":     "object",
+		"required": []any{"status", "trackingNumber"},
+		"properties": map[string]any{
+			"status": map[string]any{"type": "string"},
+		},
+	}
+	args := WorkflowParams{Params: map[string]string{"order_id": "42"}}
+
+	t.Run("matching result has no warning", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runResult: map[string]any{"status": "shipped", "trackingNumber": "1Z"}}
+		resp, err := workflowToolHandler("OrderWorkflow", workflow, mockClient, &config.Config{})(args)
+		require.NoError(t, err)
+		require.Len(t, resp.Content, 1)
+	})
+
+	t.Run("mismatching result is returned with a warning", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runResult: map[string]any{"status": 3}}
+		resp, err := workflowToolHandler("OrderWorkflow", workflow, mockClient, &config.Config{})(args)
+		require.NoError(t, err)
+		require.Len(t, resp.Content, 2)
+		require.JSONEq(t, `{"status": 3}`, resp.Content[0].TextContent.Text)
+		warning := resp.Content[1].TextContent.Text
+		require.Contains(t, warning, "does not match its declared output schema")
+		require.Contains(t, warning, `missing required property "trackingNumber"`)
+		require.Contains(t, warning, "$.status: expected string, got number")
+	})
+}
+
+func TestWorkflowIDMissingKeyModes(t *testing.T) {
+	def := config.WorkflowDef{WorkflowIDRecipe: "id_{{ .one }}_{{ .missing }}"}
+	params := map[string]string{"one": "1"}
+
+	t.Run("default keeps <no value>", func(t *testing.T) {
+		actual, err := computeWorkflowID(def, params, nil, workflowIDOptions{MissingKey: config.MissingKeyDefault})
+		require.NoError(t, err)
+		require.Equal(t, "id_1_<no value>", actual)
+	})
+
+	t.Run("error mode rejects missing keys", func(t *testing.T) {
+		_, err := computeWorkflowID(def, params, nil, workflowIDOptions{MissingKey: config.MissingKeyError})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not provided")
+		require.Contains(t, err.Error(), `"missing"`)
+	})
+
+	t.Run("error mode accepts complete params", func(t *testing.T) {
+		actual, err := computeWorkflowID(def, map[string]string{"one": "1", "missing": "2"}, nil, workflowIDOptions{MissingKey: config.MissingKeyError})
+		require.NoError(t, err)
+		require.Equal(t, "id_1_2", actual)
+	})
+
+	t.Run("replace mode with empty replacement", func(t *testing.T) {
+		actual, err := computeWorkflowID(def, params, nil, workflowIDOptions{MissingKey: config.MissingKeyReplace})
+		require.NoError(t, err)
+		require.Equal(t, "id_1_", actual)
+	})
+
+	t.Run("replace mode with placeholder covers empty values too", func(t *testing.T) {
+		opts := workflowIDOptions{MissingKey: config.MissingKeyReplace, MissingValue: "none"}
+		actual, err := computeWorkflowID(def, map[string]string{"one": ""}, nil, opts)
+		require.NoError(t, err)
+		require.Equal(t, "id_none_none", actual)
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		_, err := computeWorkflowID(def, params, nil, workflowIDOptions{MissingKey: "bogus"})
+		require.Error(t, err)
+	})
+}
+
+func TestWorkflowIDRecipeBounds(t *testing.T) {
+	params := map[string]string{"id": "1"}
+
+	t.Run("oversized output is rejected", func(t *testing.T) {
+		def := config.WorkflowDef{WorkflowIDRecipe: "{{ range 100000 }}id_{{ $.id }}{{ end }}"}
+		_, err := computeWorkflowID(def, params, nil, workflowIDOptions{})
+		require.ErrorContains(t, err, "produced more than 65536 bytes")
+	})
+
+	t.Run("long but bounded output is shortened", func(t *testing.T) {
+		def := config.WorkflowDef{WorkflowIDRecipe: "{{ range 500 }}id_{{ $.id }}{{ end }}"}
+		actual, err := computeWorkflowID(def, params, nil, workflowIDOptions{})
+		require.NoError(t, err)
+		require.Len(t, actual, maxWorkflowIDLength)
+	})
+
+	t.Run("slow recipe times out", func(t *testing.T) {
+		def := config.WorkflowDef{WorkflowIDRecipe: "{{ range 10000000 }}{{ end }}id_{{ .id }}"}
+		_, err := computeWorkflowID(def, params, nil, workflowIDOptions{Timeout: time.Millisecond})
+		require.ErrorContains(t, err, "took longer than 1ms")
+	})
+}
+
+func TestWorkflowIDMaxLength(t *testing.T) {
+	def := config.WorkflowDef{WorkflowIDRecipe: "report_{{ .customer }}_{{ .period }}"}
+	customer := strings.Repeat("c", 100)
+	opts := workflowIDOptions{MaxLength: 64}
+
+	first, err := computeWorkflowID(def, map[string]string{"customer": customer, "period": "2025-01"}, nil, opts)
+	require.NoError(t, err)
+	require.Len(t, first, 64)
+	require.True(t, strings.HasPrefix(first, "report_ccc"))
+	require.Regexp(t, `_[0-9a-f]{16}$`, first)
+
+	again, err := computeWorkflowID(def, map[string]string{"customer": customer, "period": "2025-01"}, nil, opts)
+	require.NoError(t, err)
+	require.Equal(t, first, again, "shortening must be deterministic")
+
+	// Differs only beyond the cut - the hash keeps the IDs apart
+	other, err := computeWorkflowID(def, map[string]string{"customer": customer, "period": "2025-02"}, nil, opts)
+	require.NoError(t, err)
+	require.Len(t, other, 64)
+	require.Equal(t, first[:47], other[:47])
+	require.NotEqual(t, first, other)
+
+	short, err := computeWorkflowID(def, map[string]string{"customer": "acme", "period": "2025-01"}, nil, opts)
+	require.NoError(t, err)
+	require.Equal(t, "report_acme_2025-01", short)
+}
+
+func TestFallbackWorkflowID(t *testing.T) {
+	params := map[string]string{"customer": "42", "sku": "abc"}
+
+	t.Run("deterministic by default", func(t *testing.T) {
+		first, err := fallbackWorkflowID("OrderWorkflow", params, workflowIDOptions{})
+		require.NoError(t, err)
+		second, err := fallbackWorkflowID("OrderWorkflow", map[string]string{"sku": "abc", "customer": "42"}, workflowIDOptions{})
+		require.NoError(t, err)
+		require.Equal(t, first, second)
+
+		id, err := uuid.Parse(first)
+		require.NoError(t, err)
+		require.Equal(t, uuid.Version(5), id.Version())
+	})
+
+	t.Run("deterministic IDs depend on name, params and salt", func(t *testing.T) {
+		opts := workflowIDOptions{Fallback: config.WorkflowIDFallbackDeterministic}
+		base, err := fallbackWorkflowID("OrderWorkflow", params, opts)
+		require.NoError(t, err)
+
+		otherName, err := fallbackWorkflowID("RefundWorkflow", params, opts)
+		require.NoError(t, err)
+		otherParams, err := fallbackWorkflowID("OrderWorkflow", map[string]string{"customer": "43", "sku": "abc"}, opts)
+		require.NoError(t, err)
+		opts.HashSalt = "staging"
+		otherSalt, err := fallbackWorkflowID("OrderWorkflow", params, opts)
+		require.NoError(t, err)
+
+		require.NotEqual(t, base, otherName)
+		require.NotEqual(t, base, otherParams)
+		require.NotEqual(t, base, otherSalt)
+	})
+
+	t.Run("random mode differs each call", func(t *testing.T) {
+		opts := workflowIDOptions{Fallback: config.WorkflowIDFallbackRandom}
+		first, err := fallbackWorkflowID("OrderWorkflow", params, opts)
+		require.NoError(t, err)
+		second, err := fallbackWorkflowID("OrderWorkflow", params, opts)
+		require.NoError(t, err)
+		require.NotEqual(t, first, second)
+
+		id, err := uuid.Parse(first)
+		require.NoError(t, err)
+		require.Equal(t, uuid.Version(4), id.Version())
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		_, err := fallbackWorkflowID("OrderWorkflow", params, workflowIDOptions{Fallback: "bogus"})
+		require.Error(t, err)
+	})
+}
+
+func TestRegisterWorkflowToolsLimit(t *testing.T) {
+	workflows := map[string]config.WorkflowDef{"A": testWorkflow(), "B": testWorkflow(), "C": testWorkflow()}
+
+	t.Run("fails past the limit", func(t *testing.T) {
+		server := mcp.NewServer(mcphttp.NewHTTPTransport("/mcp"))
+		err := registerWorkflowTools(server, &config.Config{MaxWorkflows: 2, Workflows: workflows}, nil)
+		require.ErrorIs(t, err, errTooManyWorkflows)
+		require.False(t, server.CheckToolRegistered("A"))
+	})
+
+	t.Run("truncates when configured", func(t *testing.T) {
+		server := mcp.NewServer(mcphttp.NewHTTPTransport("/mcp"))
+		err := registerWorkflowTools(server, &config.Config{MaxWorkflows: 2, TruncateWorkflows: true, Workflows: workflows}, nil)
+		require.NoError(t, err)
+		require.True(t, server.CheckToolRegistered("A"))
+		require.True(t, server.CheckToolRegistered("B"))
+		require.False(t, server.CheckToolRegistered("C"))
+	})
+
+	t.Run("within the limit", func(t *testing.T) {
+		server := mcp.NewServer(mcphttp.NewHTTPTransport("/mcp"))
+		require.NoError(t, registerWorkflowTools(server, &config.Config{Workflows: workflows}, nil))
+		require.True(t, server.CheckToolRegistered("C"))
+	})
+}
+
+func TestWorkflowToolErrorHints(t *testing.T) {
+	workflow := testWorkflow()
+	workflow.ErrorHints = config.ErrorHintsDef{
+		MissingParams: "Order IDs look like ORD-123.",
+		Failure:       "Check the order exists first.",
+	}
+	mockClient := &mockTemporalClient{runErr: errors.New("order not found")}
+	handler := workflowToolHandler("OrderWorkflow", workflow, mockClient, nil)
+
+	resp, err := asResponse(handler(WorkflowParams{Params: map[string]string{}}))
+	require.NoError(t, err)
+	require.Equal(t, "Error: Missing required parameters for workflow OrderWorkflow: order_id\nOrder IDs look like ORD-123.",
+		resp.Content[0].TextContent.Text)
+
+	resp, err = asResponse(handler(WorkflowParams{Params: map[string]string{"order_id": "1"}}))
+	require.NoError(t, err)
+	require.Equal(t, "Workflow failed: order not found\nCheck the order exists first.", resp.Content[0].TextContent.Text)
+
+	// Without hints the standard messages are unchanged
+	resp, err = asResponse(workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, nil)(WorkflowParams{Params: map[string]string{}}))
+	require.NoError(t, err)
+	require.Equal(t, "Error: Missing required parameters for workflow OrderWorkflow: order_id", resp.Content[0].TextContent.Text)
+}
+
+func TestWorkflowToolFailureQuery(t *testing.T) {
+	workflow := testWorkflow()
+	workflow.FailureQuery = "progress"
+	args := WorkflowParams{Params: map[string]string{"order_id": "1"}}
+
+	t.Run("partial state is returned with the failure", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runErr: errors.New("payment declined"), queryResult: map[string]any{"reserved": 3}}
+		resp, err := asResponse(workflowToolHandler("OrderWorkflow", workflow, mockClient, nil)(args))
+		require.NoError(t, err)
+		require.Len(t, resp.Content, 2)
+		require.Equal(t, "Workflow failed: payment declined", resp.Content[0].TextContent.Text)
+		require.Equal(t, "Partial state (from query progress):\n{\"reserved\":3}", resp.Content[1].TextContent.Text)
+		require.Equal(t, "progress", mockClient.lastQueryType)
+		require.Equal(t, "mock-run-id", mockClient.lastQueryRunID)
+	})
+
+	t.Run("query failure is reported", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runErr: errors.New("payment declined"), queryErr: errors.New("no worker")}
+		resp, err := asResponse(workflowToolHandler("OrderWorkflow", workflow, mockClient, nil)(args))
+		require.NoError(t, err)
+		require.Len(t, resp.Content, 2)
+		require.Equal(t, "Workflow failed: payment declined", resp.Content[0].TextContent.Text)
+		require.Contains(t, resp.Content[1].TextContent.Text, "query progress failed: no worker")
+	})
+
+	t.Run("no query configured", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runErr: errors.New("payment declined")}
+		resp, err := asResponse(workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, nil)(args))
+		require.NoError(t, err)
+		require.Len(t, resp.Content, 1)
+		require.Empty(t, mockClient.lastQueryType)
+	})
+}
+
+func TestSystemPromptIsDeterministic(t *testing.T) {
+	cfg := &config.Config{Workflows: map[string]config.WorkflowDef{}}
+	for _, name := range []string{"Delta", "Alpha", "Echo", "Charlie", "Bravo"} {
+		cfg.Workflows[name+"Workflow"] = config.WorkflowDef{
+			Purpose: name + " things.",
+			Input: config.ParameterDef{
+				Type:   name + "Request",
+				Fields: []map[string]string{{"z_param": "Z", "a_param": "A", "m_param": "Optional M"}},
+			},
+		}
+	}
+
+	prompt := buildSystemPrompt(cfg)
+	var positions []int
+	for _, name := range []string{"Alpha", "Bravo", "Charlie", "Delta", "Echo"} {
+		pos := strings.Index(prompt, "## "+name+"Workflow")
+		require.GreaterOrEqual(t, pos, 0, "missing %s", name)
+		positions = append(positions, pos)
+	}
+	require.True(t, sort.IntsAreSorted(positions), "workflows are not listed in sorted order")
+
+	for i := 0; i < 10; i++ {
+		require.Equal(t, prompt, buildSystemPrompt(cfg))
+	}
+}
+
+func TestWorkflowParamsFromJSONString(t *testing.T) {
+	t.Run("stringified object is decoded", func(t *testing.T) {
+		var args WorkflowParams
+		require.NoError(t, json.Unmarshal([]byte(`{"params": "{\"order_id\": \"42\", \"note\": \"rush\"}", "force_rerun": true}`), &args))
+		require.Equal(t, ParamsMap{"order_id": "42", "note": "rush"}, args.Params)
+		require.True(t, *args.ForceRerun)
+
+		mockClient := &mockTemporalClient{runResult: "ok"}
+		resp, err := workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, nil)(args)
+		require.NoError(t, err)
+		require.Equal(t, "ok", resp.Content[0].TextContent.Text)
+		require.Equal(t, "order_42", mockClient.lastStartOptions.ID)
+	})
+
+	t.Run("objects are unchanged", func(t *testing.T) {
+		var args WorkflowParams
+		require.NoError(t, json.Unmarshal([]byte(`{"params": {"order_id": "42"}}`), &args))
+		require.Equal(t, ParamsMap{"order_id": "42"}, args.Params)
+	})
+
+	t.Run("string that isn't an object", func(t *testing.T) {
+		var args WorkflowParams
+		require.Error(t, json.Unmarshal([]byte(`{"params": "order 42"}`), &args))
+	})
+}
+
+func TestWorkflowParamsCoercesScalars(t *testing.T) {
+	t.Run("numbers, booleans and nulls", func(t *testing.T) {
+		var args WorkflowParams
+		require.NoError(t, json.Unmarshal([]byte(
+			`{"params": {"order_id": 42, "amount": 19.99, "big": 12345678901234567890, "rush": true, "gift": false, "note": "hi", "coupon": null}}`,
+		), &args))
+		require.Equal(t, ParamsMap{
+			"order_id": "42",
+			"amount":   "19.99",
+			"big":      "12345678901234567890",
+			"rush":     "true",
+			"gift":     "false",
+			"note":     "hi",
+			"coupon":   "",
+		}, args.Params)
+
+		mockClient := &mockTemporalClient{runResult: "ok"}
+		_, err := workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, nil)(args)
+		require.NoError(t, err)
+		require.Equal(t, "order_42", mockClient.lastStartOptions.ID)
+	})
+
+	t.Run("inside a JSON-encoded string", func(t *testing.T) {
+		var args WorkflowParams
+		require.NoError(t, json.Unmarshal([]byte(`{"params": "{\"order_id\": 7}"}`), &args))
+		require.Equal(t, ParamsMap{"order_id": "7"}, args.Params)
+	})
+
+	t.Run("nested values are rejected", func(t *testing.T) {
+		var args WorkflowParams
+		err := json.Unmarshal([]byte(`{"params": {"order_id": "42", "items": [1, 2]}}`), &args)
+		require.ErrorContains(t, err, "param items must be a string, number or boolean")
+	})
+}
+
+func TestWorkflowToolStrictParams(t *testing.T) {
+	args := WorkflowParams{Params: map[string]string{"order_id": "42", "orderID": "42", "note": "rush"}}
+
+	// Lenient by default: unknown keys are ignored
+	mockClient := &mockTemporalClient{runResult: "ok"}
+	resp, err := workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, &config.Config{})(args)
+	require.NoError(t, err)
+	require.Equal(t, "ok", resp.Content[0].TextContent.Text)
+
+	// Strict mode rejects them without starting the workflow
+	mockClient = &mockTemporalClient{runResult: "ok"}
+	resp, err = asResponse(workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, &config.Config{StrictParams: true})(args))
+	require.NoError(t, err)
+	require.Equal(t, "Error: Unexpected parameters for workflow OrderWorkflow: note, orderID (valid parameters: order_id)",
+		resp.Content[0].TextContent.Text)
+	require.Empty(t, mockClient.lastStartOptions.ID)
+
+	// Declared params pass
+	resp, err = workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, &config.Config{StrictParams: true})(
+		WorkflowParams{Params: map[string]string{"order_id": "42"}})
+	require.NoError(t, err)
+	require.Equal(t, "ok", resp.Content[0].TextContent.Text)
+}
+
+func TestWorkflowDefaultForceRerun(t *testing.T) {
+	rerun := func(t *testing.T, workflow config.WorkflowDef, forceRerun *bool) bool {
+		mockClient := &mockTemporalClient{runResult: "ok"}
+		_, err := workflowToolHandler("OrderWorkflow", workflow, mockClient, nil)(
+			WorkflowParams{Params: map[string]string{"order_id": "42"}, ForceRerun: forceRerun})
+		require.NoError(t, err)
+		return mockClient.lastStartOptions.WorkflowIDConflictPolicy == temporal_enums.WORKFLOW_ID_CONFLICT_POLICY_TERMINATE_EXISTING
+	}
+	yes, no := true, false
+
+	require.False(t, rerun(t, testWorkflow(), nil), "workflows dedupe by default")
+
+	alwaysRerun := testWorkflow()
+	alwaysRerun.DefaultForceRerun = true
+	require.True(t, rerun(t, alwaysRerun, nil), "defaultForceRerun applies when the caller omits force_rerun")
+	require.False(t, rerun(t, alwaysRerun, &no), "an explicit force_rerun wins")
+	require.True(t, rerun(t, testWorkflow(), &yes))
+}
+
+func TestRegisterWorkflowToolsRequiresTaskQueue(t *testing.T) {
+	noQueue := testWorkflow()
+	noQueue.TaskQueue = ""
+	cfg := &config.Config{Workflows: map[string]config.WorkflowDef{"OrderWorkflow": testWorkflow(), "RefundWorkflow": noQueue}}
+
+	err := registerWorkflowTools(mcp.NewServer(mcphttp.NewHTTPTransport("/mcp")), cfg, nil)
+	require.ErrorIs(t, err, errNoTaskQueue)
+	require.ErrorContains(t, err, "failed to register workflow tool RefundWorkflow: no task queue")
+
+	// A default task queue covers workflows without their own
+	cfg.Temporal.DefaultTaskQueue = "default-queue"
+	require.NoError(t, registerWorkflowTools(mcp.NewServer(mcphttp.NewHTTPTransport("/mcp")), cfg, nil))
+}
+
+func TestWorkflowExecutionTimeoutBoundsWait(t *testing.T) {
+	workflow := testWorkflow()
+	workflow.ExecutionTimeout = "50ms"
+	mockClient := &mockTemporalClient{runBlocks: true}
+	resp, err := asResponse(workflowToolHandler("OrderWorkflow", workflow, mockClient, &config.Config{})(WorkflowParams{Params: map[string]string{"order_id": "42"}}))
+	require.NoError(t, err)
+	require.Equal(t, 50*time.Millisecond, mockClient.lastStartOptions.WorkflowExecutionTimeout)
+	require.Equal(t, "Error: workflow OrderWorkflow (WorkflowID=order_42 RunID=mock-run-id) did not complete within 50ms",
+		resp.Content[0].TextContent.Text)
+}
+
+func TestWorkflowAsyncStart(t *testing.T) {
+	// The run would fail if waited on, so a successful response shows the call didn't wait
+	mockClient := &mockTemporalClient{runErr: errors.New("still running")}
+	args := WorkflowParams{Params: map[string]string{"order_id": "42"}, Async: true}
+	resp, err := workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, &config.Config{})(args)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"workflowId": "order_42", "runId": "mock-run-id"}`, resp.Content[0].TextContent.Text)
+
+	// Workflows in another namespace say so, since follow-up tools need it to find them
+	workflow := testWorkflow()
+	workflow.Namespace = "billing"
+	resp, err = workflowToolHandler("OrderWorkflow", workflow, mockClient, &config.Config{})(args)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"workflowId": "order_42", "runId": "mock-run-id", "namespace": "billing"}`, resp.Content[0].TextContent.Text)
+
+	// Synchronous by default
+	args.Async = false
+	resp, err = asResponse(workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, &config.Config{})(args))
+	require.NoError(t, err)
+	require.Equal(t, "Workflow failed: still running", resp.Content[0].TextContent.Text)
+}
+
+func TestResolveNamespace(t *testing.T) {
+	t.Setenv("TEST_POD_NAMESPACE", "tenant-a")
+
+	require.Equal(t, "from-flag", resolveNamespace("from-flag", "${TEST_POD_NAMESPACE}"), "the flag beats the config")
+	require.Equal(t, "tenant-a", resolveNamespace("", "${TEST_POD_NAMESPACE}"), "env references are resolved")
+	require.Equal(t, "orders", resolveNamespace("", "orders"))
+	require.Equal(t, client.DefaultNamespace, resolveNamespace("", ""), "the config beats the default")
+	require.Equal(t, client.DefaultNamespace, resolveNamespace("", "${TEST_UNSET_NAMESPACE}"), "unset variables fall back to the default")
+}
+
+func TestWorkflowEnvBackedDefaults(t *testing.T) {
+	workflow := testWorkflow()
+	workflow.WorkflowIDRecipe = "order_{{ .order_id }}_{{ .tenant }}"
+	workflow.Input.Fields = append(workflow.Input.Fields, map[string]string{"tenant": "The tenant"})
+	workflow.Defaults = map[string]string{"tenant": "${TEST_TENANT_ID}"}
+	handler := workflowToolHandler("OrderWorkflow", workflow, &mockTemporalClient{runResult: "ok"}, nil)
+
+	// Resolved at call time, not when the workflow is registered
+	t.Setenv("TEST_TENANT_ID", "acme")
+	mockClient := &mockTemporalClient{runResult: "ok"}
+	_, err := workflowToolHandler("OrderWorkflow", workflow, mockClient, nil)(WorkflowParams{Params: map[string]string{"order_id": "42"}})
+	require.NoError(t, err)
+	require.Equal(t, "order_42_acme", mockClient.lastStartOptions.ID)
+	require.Equal(t, []any{ParamsMap{"order_id": "42", "tenant": "acme"}}, mockClient.lastWorkflowArgs)
+
+	// An explicit param wins
+	mockClient = &mockTemporalClient{runResult: "ok"}
+	_, err = workflowToolHandler("OrderWorkflow", workflow, mockClient, nil)(WorkflowParams{Params: map[string]string{"order_id": "42", "tenant": "globex"}})
+	require.NoError(t, err)
+	require.Equal(t, "order_42_globex", mockClient.lastStartOptions.ID)
+
+	// An unset variable leaves the required param missing
+	require.NoError(t, os.Unsetenv("TEST_TENANT_ID"))
+	resp, err := asResponse(handler(WorkflowParams{Params: map[string]string{"order_id": "42"}}))
+	require.NoError(t, err)
+	require.Equal(t, "Error: Missing required parameters for workflow OrderWorkflow: tenant", resp.Content[0].TextContent.Text)
+}
@@ -0,0 +1,392 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	temporal_enums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/history/v1"
+	"go.temporal.io/api/namespace/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/taskqueue/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// mockTemporalClient is a partial client.Client for testing tool handlers. Embedding the interface satisfies it;
+// calling a method that isn't overridden below panics, which flags tests that reach further than intended.
+type mockTemporalClient struct {
+	client.Client
+
+	// historyEvents are served by every history iterator. An error in historyErrs is returned (once, across all
+	// iterators) in place of the event at the same index, after which that iterator stops - like the SDK's does.
+	historyEvents     []*history.HistoryEvent
+	historyErrs       map[int]error
+	historyBlocks     bool // Once the events run out, iterators wait for their context, like a long poll with no new events
+	historyCalls      int
+	lastHistoryRunID  string
+	lastHistoryPolled bool
+
+	healthErr error
+
+	executeErr       error
+	runResult        any
+	runErr           error
+	runBlocks        bool // Get waits for its context instead of returning runResult/runErr
+	lastStartOptions client.StartWorkflowOptions
+	lastWorkflowName string
+	lastWorkflowArgs []any
+
+	queryResult    any
+	queryErr       error
+	lastQueryType  string
+	lastQueryRunID string
+	lastQueryArgs  []any
+
+	signalErr       error
+	lastSignalName  string
+	lastSignalRunID string
+	lastSignalArg   any
+
+	// lastSignalRequest is set only by signals sent without an argument, through the workflow service
+	lastSignalRequest *workflowservice.SignalWorkflowExecutionRequest
+
+	stopErr           error
+	lastCancelRunID   string
+	lastTerminateRun  string
+	lastTerminateWhy  string
+	lastTerminateArgs []any
+
+	countResponse    *workflowservice.CountWorkflowExecutionsResponse
+	countErr         error
+	lastCountRequest *workflowservice.CountWorkflowExecutionsRequest
+
+	scheduleDescription *client.ScheduleDescription
+	scheduleErr         error
+	lastScheduleID      string
+	lastScheduleOp      string
+	lastScheduleNote    string
+
+	describeResponse *workflowservice.DescribeWorkflowExecutionResponse
+	describeErr      error
+	lastDescribeID   string
+	lastDescribeRun  string
+	// describeResponses, when set, answers per workflow ID; IDs not in it are not found. Safe for concurrent calls.
+	describeResponses map[string]*workflowservice.DescribeWorkflowExecutionResponse
+	describeMu        sync.Mutex
+	// describeSequence, when set, answers successive calls in order, repeating its last entry
+	describeSequence []*workflowservice.DescribeWorkflowExecutionResponse
+	describeCalls    int
+
+	namespaceRetention time.Duration
+
+	batchRequests []*workflowservice.StartBatchOperationRequest
+
+	// taskQueuePollers maps a task queue to its pollers; queues not in the map have none. The first
+	// idleTaskQueueCalls calls report no pollers at all, like before a worker comes up.
+	taskQueuePollers       map[string][]*taskqueue.PollerInfo
+	idleTaskQueueCalls     int
+	describeTaskQueueCalls []string
+
+	taskQueueDescription      client.TaskQueueDescription
+	taskQueueDescriptionErr   error
+	lastDescribeTaskQueueOpts client.DescribeTaskQueueEnhancedOptions
+}
+
+// CheckHealth succeeds unless healthErr is set
+func (m *mockTemporalClient) CheckHealth(ctx context.Context, request *client.CheckHealthRequest) (*client.CheckHealthResponse, error) {
+	if m.healthErr != nil {
+		return nil, m.healthErr
+	}
+	return &client.CheckHealthResponse{}, nil
+}
+
+// CountWorkflow returns countResponse or countErr and records the request
+func (m *mockTemporalClient) CountWorkflow(ctx context.Context, request *workflowservice.CountWorkflowExecutionsRequest) (*workflowservice.CountWorkflowExecutionsResponse, error) {
+	m.lastCountRequest = request
+	if m.countErr != nil {
+		return nil, m.countErr
+	}
+	return m.countResponse, nil
+}
+
+// DescribeWorkflowExecution returns describeResponse or describeErr (or the entry in describeSequence or
+// describeResponses) and records the workflow ID
+func (m *mockTemporalClient) DescribeWorkflowExecution(ctx context.Context, workflowID, runID string) (*workflowservice.DescribeWorkflowExecutionResponse, error) {
+	m.describeMu.Lock()
+	defer m.describeMu.Unlock()
+	m.lastDescribeID = workflowID
+	m.lastDescribeRun = runID
+	m.describeCalls++
+	if len(m.describeSequence) > 0 {
+		return m.describeSequence[min(m.describeCalls, len(m.describeSequence))-1], nil
+	}
+	if m.describeResponses != nil {
+		resp, ok := m.describeResponses[workflowID]
+		if !ok {
+			return nil, serviceerror.NewNotFound(fmt.Sprintf("workflow not found for ID: %s", workflowID))
+		}
+		return resp, nil
+	}
+	if m.describeErr != nil {
+		return nil, m.describeErr
+	}
+	return m.describeResponse, nil
+}
+
+// WorkflowService returns a service client whose DescribeNamespace reports namespaceRetention
+func (m *mockTemporalClient) WorkflowService() workflowservice.WorkflowServiceClient {
+	return &mockWorkflowService{parent: m}
+}
+
+// mockWorkflowService is a partial workflowservice.WorkflowServiceClient; see mockTemporalClient
+type mockWorkflowService struct {
+	workflowservice.WorkflowServiceClient
+	parent *mockTemporalClient
+}
+
+// DescribeNamespace returns the namespace with the parent mock's retention
+func (s *mockWorkflowService) DescribeNamespace(ctx context.Context, request *workflowservice.DescribeNamespaceRequest, opts ...grpc.CallOption) (*workflowservice.DescribeNamespaceResponse, error) {
+	return &workflowservice.DescribeNamespaceResponse{
+		NamespaceInfo: &namespace.NamespaceInfo{Name: request.GetNamespace()},
+		Config:        &namespace.NamespaceConfig{WorkflowExecutionRetentionTtl: durationpb.New(s.parent.namespaceRetention)},
+	}, nil
+}
+
+// StartBatchOperation records the request on the parent mock
+func (s *mockWorkflowService) StartBatchOperation(ctx context.Context, request *workflowservice.StartBatchOperationRequest, opts ...grpc.CallOption) (*workflowservice.StartBatchOperationResponse, error) {
+	s.parent.batchRequests = append(s.parent.batchRequests, request)
+	return &workflowservice.StartBatchOperationResponse{}, nil
+}
+
+// SignalWorkflowExecution records an argument-less signal on the parent mock like SignalWorkflow does and returns its
+// signalErr
+func (s *mockWorkflowService) SignalWorkflowExecution(ctx context.Context, request *workflowservice.SignalWorkflowExecutionRequest, opts ...grpc.CallOption) (*workflowservice.SignalWorkflowExecutionResponse, error) {
+	s.parent.lastSignalName = request.GetSignalName()
+	s.parent.lastSignalRunID = request.GetWorkflowExecution().GetRunId()
+	s.parent.lastSignalArg = nil
+	s.parent.lastSignalRequest = request
+	if s.parent.signalErr != nil {
+		return nil, s.parent.signalErr
+	}
+	return &workflowservice.SignalWorkflowExecutionResponse{}, nil
+}
+
+// DescribeTaskQueue returns the pollers configured for the task queue, once idleTaskQueueCalls have passed, and records
+// the call
+func (m *mockTemporalClient) DescribeTaskQueue(ctx context.Context, taskQueue string, taskQueueType temporal_enums.TaskQueueType) (*workflowservice.DescribeTaskQueueResponse, error) {
+	m.describeTaskQueueCalls = append(m.describeTaskQueueCalls, taskQueue)
+	if len(m.describeTaskQueueCalls) <= m.idleTaskQueueCalls {
+		return &workflowservice.DescribeTaskQueueResponse{}, nil
+	}
+	return &workflowservice.DescribeTaskQueueResponse{Pollers: m.taskQueuePollers[taskQueue]}, nil
+}
+
+// DescribeTaskQueueEnhanced returns the configured task queue description and records the request
+func (m *mockTemporalClient) DescribeTaskQueueEnhanced(ctx context.Context, options client.DescribeTaskQueueEnhancedOptions) (client.TaskQueueDescription, error) {
+	m.lastDescribeTaskQueueOpts = options
+	return m.taskQueueDescription, m.taskQueueDescriptionErr
+}
+
+// GetWorkflowHistory returns the configured history iterator and records how it was requested
+func (m *mockTemporalClient) GetWorkflowHistory(ctx context.Context, workflowID string, runID string, isLongPoll bool, filterType temporal_enums.HistoryEventFilterType) client.HistoryEventIterator {
+	m.historyCalls++
+	m.lastHistoryRunID = runID
+	m.lastHistoryPolled = isLongPoll
+	return &mockHistoryIterator{ctx: ctx, events: m.historyEvents, errs: m.historyErrs, blocks: m.historyBlocks}
+}
+
+// ExecuteWorkflow records the start request and returns a run that completes with runResult/runErr
+func (m *mockTemporalClient) ExecuteWorkflow(ctx context.Context, options client.StartWorkflowOptions, workflow interface{}, args ...interface{}) (client.WorkflowRun, error) {
+	m.lastStartOptions = options
+	m.lastWorkflowName, _ = workflow.(string)
+	m.lastWorkflowArgs = args
+	if m.executeErr != nil {
+		return nil, m.executeErr
+	}
+	return &mockWorkflowRun{id: options.ID, runID: "mock-run-id", result: m.runResult, err: m.runErr, blocks: m.runBlocks}, nil
+}
+
+// mockWorkflowRun is a completed workflow run, or one that never completes if blocks is set
+type mockWorkflowRun struct {
+	id     string
+	runID  string
+	result any
+	err    error
+	blocks bool
+}
+
+// GetID returns the workflow ID
+func (m *mockWorkflowRun) GetID() string {
+	return m.id
+}
+
+// GetRunID returns the run ID
+func (m *mockWorkflowRun) GetRunID() string {
+	return m.runID
+}
+
+// Get decodes the run's result into valuePtr, or returns its error
+func (m *mockWorkflowRun) Get(ctx context.Context, valuePtr interface{}) error {
+	return m.GetWithOptions(ctx, valuePtr, client.WorkflowRunGetOptions{})
+}
+
+// GetWithOptions decodes the run's result into valuePtr, or returns its error
+func (m *mockWorkflowRun) GetWithOptions(ctx context.Context, valuePtr interface{}, options client.WorkflowRunGetOptions) error {
+	if m.blocks {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	if m.err != nil {
+		return m.err
+	}
+	return (&mockEncodedValue{value: m.result}).Get(valuePtr)
+}
+
+// QueryWorkflow returns queryResult (round-tripped through the data converter on Get) or queryErr, recording the query
+func (m *mockTemporalClient) QueryWorkflow(ctx context.Context, workflowID string, runID string, queryType string, args ...interface{}) (converter.EncodedValue, error) {
+	m.lastQueryType = queryType
+	m.lastQueryRunID = runID
+	m.lastQueryArgs = args
+	if m.queryErr != nil {
+		return nil, m.queryErr
+	}
+	return &mockEncodedValue{value: m.queryResult}, nil
+}
+
+// SignalWorkflow records the signal and returns signalErr
+func (m *mockTemporalClient) SignalWorkflow(ctx context.Context, workflowID string, runID string, signalName string, arg interface{}) error {
+	m.lastSignalName = signalName
+	m.lastSignalRunID = runID
+	m.lastSignalArg = arg
+	m.lastSignalRequest = nil
+	return m.signalErr
+}
+
+// CancelWorkflow records the run and returns stopErr
+func (m *mockTemporalClient) CancelWorkflow(ctx context.Context, workflowID string, runID string) error {
+	m.lastCancelRunID = runID
+	return m.stopErr
+}
+
+// TerminateWorkflow records the run, reason and details and returns stopErr
+func (m *mockTemporalClient) TerminateWorkflow(ctx context.Context, workflowID string, runID string, reason string, details ...interface{}) error {
+	m.lastTerminateRun = runID
+	m.lastTerminateWhy = reason
+	m.lastTerminateArgs = details
+	return m.stopErr
+}
+
+// mockEncodedValue decodes its value the way a value round-tripped through the default data converter would be
+// decoded: []byte stays binary, everything else goes through JSON
+type mockEncodedValue struct {
+	value any
+}
+
+// HasValue reports whether a value is present
+func (m *mockEncodedValue) HasValue() bool {
+	return m.value != nil
+}
+
+// Get round-trips the value through the default data converter into valuePtr
+func (m *mockEncodedValue) Get(valuePtr interface{}) error {
+	dataConverter := converter.GetDefaultDataConverter()
+	payload, err := dataConverter.ToPayload(m.value)
+	if err != nil {
+		return err
+	}
+	return dataConverter.FromPayload(payload, valuePtr)
+}
+
+// mockHistoryIterator yields a fixed list of events. An entry in errs at the same index as an event is returned
+// instead of that event the first time it is reached, and ends the iteration.
+type mockHistoryIterator struct {
+	ctx    context.Context
+	events []*history.HistoryEvent
+	errs   map[int]error
+	pos    int
+	failed bool
+	blocks bool
+}
+
+// HasNext reports whether any events remain; a blocking iterator always has more until it fails
+func (m *mockHistoryIterator) HasNext() bool {
+	return !m.failed && (m.blocks || m.pos < len(m.events))
+}
+
+// Next returns the next event, or the error queued for its position. Past the last event, a blocking iterator waits
+// for its context and returns the context's error.
+func (m *mockHistoryIterator) Next() (*history.HistoryEvent, error) {
+	if m.blocks && m.pos >= len(m.events) {
+		<-m.ctx.Done()
+		m.failed = true
+		return nil, m.ctx.Err()
+	}
+	if err, ok := m.errs[m.pos]; ok {
+		delete(m.errs, m.pos)
+		m.failed = true
+		return nil, err
+	}
+	event := m.events[m.pos]
+	m.pos++
+	return event, nil
+}
+
+// historyEvent builds a minimal history event for tests
+func historyEvent(id int64, eventType temporal_enums.EventType) *history.HistoryEvent {
+	return &history.HistoryEvent{EventId: id, EventType: eventType}
+}
+
+// ScheduleClient returns a schedule client whose handles are backed by this mock
+func (m *mockTemporalClient) ScheduleClient() client.ScheduleClient {
+	return &mockScheduleClient{parent: m}
+}
+
+// mockScheduleClient is a partial client.ScheduleClient; see mockTemporalClient
+type mockScheduleClient struct {
+	client.ScheduleClient
+	parent *mockTemporalClient
+}
+
+// GetHandle records the schedule ID and returns a handle backed by the parent mock
+func (c *mockScheduleClient) GetHandle(ctx context.Context, scheduleID string) client.ScheduleHandle {
+	c.parent.lastScheduleID = scheduleID
+	return &mockScheduleHandle{id: scheduleID, parent: c.parent}
+}
+
+// mockScheduleHandle is a partial client.ScheduleHandle; see mockTemporalClient
+type mockScheduleHandle struct {
+	client.ScheduleHandle
+	id     string
+	parent *mockTemporalClient
+}
+
+// GetID returns the schedule ID the handle was created for
+func (h *mockScheduleHandle) GetID() string {
+	return h.id
+}
+
+// Describe returns scheduleDescription or scheduleErr
+func (h *mockScheduleHandle) Describe(ctx context.Context) (*client.ScheduleDescription, error) {
+	if h.parent.scheduleErr != nil {
+		return nil, h.parent.scheduleErr
+	}
+	return h.parent.scheduleDescription, nil
+}
+
+// Pause records the call and returns scheduleErr
+func (h *mockScheduleHandle) Pause(ctx context.Context, options client.SchedulePauseOptions) error {
+	h.parent.lastScheduleOp, h.parent.lastScheduleNote = "pause", options.Note
+	return h.parent.scheduleErr
+}
+
+// Unpause records the call and returns scheduleErr
+func (h *mockScheduleHandle) Unpause(ctx context.Context, options client.ScheduleUnpauseOptions) error {
+	h.parent.lastScheduleOp, h.parent.lastScheduleNote = "unpause", options.Note
+	return h.parent.scheduleErr
+}
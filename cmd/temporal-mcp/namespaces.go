@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"go.temporal.io/sdk/client"
+)
+
+// NamespaceParam is embedded in the arguments of tools that act on an existing workflow, so they can reach workflows
+// started in a namespace other than the configured one
+type NamespaceParam struct {
+	Namespace string `json:"namespace,omitempty"`
+}
+
+func (p NamespaceParam) namespace() string {
+	return p.Namespace
+}
+
+// namespacedArgs is implemented by tool arguments that embed NamespaceParam
+type namespacedArgs interface {
+	namespace() string
+}
+
+// namespaceClients hands out a client per connection; *temporal.ClientPool implements it
+type namespaceClients interface {
+	Get(cfg config.TemporalConfig) (client.Client, error)
+}
+
+// workflowNamespaces returns the namespaces workflows can be started in: the configured namespace and every
+// workflow's namespace override, sorted
+func workflowNamespaces(cfg *config.Config) []string {
+	seen := map[string]bool{temporalNamespace(cfg): true}
+	for _, workflow := range cfg.Workflows {
+		if workflow.Namespace != "" {
+			seen[workflow.Namespace] = true
+		}
+	}
+	namespaces := make([]string, 0, len(seen))
+	for namespace := range seen {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// namespaceDescription is appended to the descriptions of tools taking a namespace argument. It is empty unless some
+// workflow runs outside the configured namespace, since only then does the argument matter.
+func namespaceDescription(cfg *config.Config) string {
+	namespaces := workflowNamespaces(cfg)
+	if len(namespaces) < 2 {
+		return ""
+	}
+	return fmt.Sprintf(". namespace is optional - set it to the namespace the workflow was started in when that isn't %s "+
+		"(async workflow tool calls return it); one of: %s", temporalNamespace(cfg), strings.Join(namespaces, ", "))
+}
+
+// namespacedHandler wraps a tool handler so that a call naming another configured namespace runs with that
+// namespace's client and config. Calls without a namespace, or naming the configured one, use tempClient.
+func namespacedHandler[T namespacedArgs](tempClient client.Client, clients namespaceClients, cfg *config.Config,
+	newHandler func(client.Client, *config.Config) func(T) (*mcp.ToolResponse, error)) func(T) (*mcp.ToolResponse, error) {
+	defaultHandler := newHandler(tempClient, cfg)
+	namespaces := workflowNamespaces(cfg)
+
+	return func(args T) (*mcp.ToolResponse, error) {
+		namespace := args.namespace()
+		if namespace == "" || namespace == temporalNamespace(cfg) || tempClient == nil || clients == nil {
+			return defaultHandler(args)
+		}
+
+		allowed := false
+		for _, candidate := range namespaces {
+			allowed = allowed || candidate == namespace
+		}
+		if !allowed {
+			return errorResponse(fmt.Sprintf("Error: namespace %s is not configured; use one of: %s",
+				namespace, strings.Join(namespaces, ", ")))
+		}
+
+		nsCfg := *cfg
+		nsCfg.Temporal.Namespace = namespace
+		nsClient, err := clients.Get(nsCfg.Temporal)
+		if err != nil {
+			log.Printf("Error: Failed to connect to Temporal namespace %s: %v", namespace, err)
+			return errorResponse(fmt.Sprintf("Error: Failed to connect to Temporal namespace %s: %v", namespace, err))
+		}
+		return newHandler(nsClient, &nsCfg)(args)
+	}
+}
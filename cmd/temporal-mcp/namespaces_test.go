@@ -0,0 +1,58 @@
+package main
+
+import (
+	"testing"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/client"
+)
+
+// fakeNamespaceClients returns the same client for every connection and records the namespaces asked for
+type fakeNamespaceClients struct {
+	client     client.Client
+	namespaces []string
+}
+
+func (f *fakeNamespaceClients) Get(cfg config.TemporalConfig) (client.Client, error) {
+	f.namespaces = append(f.namespaces, cfg.Namespace)
+	return f.client, nil
+}
+
+func TestNamespacedHandlerRoutesByNamespace(t *testing.T) {
+	cfg := &config.Config{
+		Temporal:  config.TemporalConfig{Namespace: "orders"},
+		Workflows: map[string]config.WorkflowDef{"InvoiceWorkflow": {Namespace: "billing"}},
+	}
+	defaultClient, billingClient := &mockTemporalClient{}, &mockTemporalClient{}
+	clients := &fakeNamespaceClients{client: billingClient}
+
+	handler := namespacedHandler(defaultClient, clients, cfg, func(tempClient client.Client, cfg *config.Config) func(GetWorkflowStackTraceParams) (*mcp.ToolResponse, error) {
+		return func(args GetWorkflowStackTraceParams) (*mcp.ToolResponse, error) {
+			if tempClient == defaultClient {
+				return mcp.NewToolResponse(mcp.NewTextContent("default " + cfg.Temporal.Namespace)), nil
+			}
+			return mcp.NewToolResponse(mcp.NewTextContent("pooled " + cfg.Temporal.Namespace)), nil
+		}
+	})
+	call := func(namespace string) string {
+		resp, err := asResponse(handler(GetWorkflowStackTraceParams{NamespaceParam: NamespaceParam{Namespace: namespace}, WorkflowID: "wf"}))
+		require.NoError(t, err)
+		return resp.Content[0].TextContent.Text
+	}
+
+	require.Equal(t, "default orders", call(""))
+	require.Equal(t, "default orders", call("orders"))
+	require.Equal(t, "pooled billing", call("billing"))
+	require.Equal(t, "Error: namespace payroll is not configured; use one of: billing, orders", call("payroll"))
+	require.Equal(t, []string{"billing"}, clients.namespaces)
+}
+
+func TestNamespaceDescription(t *testing.T) {
+	cfg := &config.Config{Workflows: map[string]config.WorkflowDef{"OrderWorkflow": {}}}
+	require.Empty(t, namespaceDescription(cfg), "the argument is only described when workflows span namespaces")
+
+	cfg.Workflows["InvoiceWorkflow"] = config.WorkflowDef{Namespace: "billing"}
+	require.Contains(t, namespaceDescription(cfg), "one of: billing, default")
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/mocksi/temporal-mcp/internal/schema"
+)
+
+// paramError is one invalid workflow param, for clients that show errors next to the offending field
+type paramError struct {
+	Param   string `json:"param"` // "" when a violation isn't about a single param
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
+}
+
+// Reasons a param is invalid
+const (
+	paramErrorMissing    = "missing"           // not sent and without a default
+	paramErrorEmpty      = "empty"             // sent as ""
+	paramErrorConstraint = "failed-constraint" // violates the workflow's input schema
+	paramErrorUnexpected = "unexpected"        // not declared by the workflow
+)
+
+// requiredParamErrors itemizes required params that are missing or empty
+func requiredParamErrors(required []string, params map[string]string) []paramError {
+	var errs []paramError
+	for _, param := range required {
+		value, exists := params[param]
+		switch {
+		case !exists:
+			errs = append(errs, paramError{Param: param, Reason: paramErrorMissing})
+		case value == "":
+			errs = append(errs, paramError{Param: param, Reason: paramErrorEmpty})
+		}
+	}
+	return errs
+}
+
+// schemaParamErrors itemizes input schema violations per param: the top-level property they are about
+func schemaParamErrors(violations []schema.Violation) []paramError {
+	errs := make([]paramError, 0, len(violations))
+	for _, violation := range violations {
+		switch {
+		case len(violation.Path) > 0:
+			errs = append(errs, paramError{Param: violation.Path[0], Reason: paramErrorConstraint, Message: violation.Message})
+		case violation.Keyword == "required":
+			errs = append(errs, paramError{Param: violation.Property, Reason: paramErrorMissing})
+		case violation.Keyword == "additionalProperties":
+			errs = append(errs, paramError{Param: violation.Property, Reason: paramErrorUnexpected})
+		default:
+			errs = append(errs, paramError{Reason: paramErrorConstraint, Message: violation.Message})
+		}
+	}
+	return errs
+}
+
+// transformParamErrors itemizes a transformParams error when a transform rejected a param's value
+func transformParamErrors(err error) []paramError {
+	var transformErr *paramTransformError
+	if !errors.As(err, &transformErr) {
+		return nil
+	}
+	return []paramError{{Param: transformErr.param, Reason: paramErrorConstraint, Message: transformErr.transform + ": " + transformErr.err.Error()}}
+}
+
+// unexpectedParamErrors itemizes params a workflow doesn't declare
+func unexpectedParamErrors(params []string) []paramError {
+	errs := make([]paramError, 0, len(params))
+	for _, param := range params {
+		errs = append(errs, paramError{Param: param, Reason: paramErrorUnexpected})
+	}
+	return errs
+}
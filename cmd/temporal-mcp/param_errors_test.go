@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"github.com/mocksi/temporal-mcp/internal/schema"
+	"github.com/stretchr/testify/require"
+)
+
+// paramErrorsOf returns the param errors a failed workflow tool call itemizes
+func paramErrorsOf(t *testing.T, err error) []paramError {
+	var toolErr *toolError
+	require.ErrorAs(t, err, &toolErr)
+	return toolErr.fields
+}
+
+func TestWorkflowParamErrorsItemized(t *testing.T) {
+	workflow := testWorkflow()
+	workflow.Input.Fields = []map[string]string{
+		{"order_id": "The order ID"},
+		{"customer_id": "The customer"},
+		{"region": "The region"},
+		{"note": "Optional note"},
+	}
+	args := WorkflowParams{Params: map[string]string{"customer_id": "", "region": "eu"}}
+
+	_, err := workflowToolHandler("OrderWorkflow", workflow, &mockTemporalClient{}, &config.Config{})(args)
+	require.Equal(t, []paramError{
+		{Param: "order_id", Reason: paramErrorMissing},
+		{Param: "customer_id", Reason: paramErrorEmpty},
+	}, paramErrorsOf(t, err))
+
+	t.Run("not in plain responses", func(t *testing.T) {
+		resp, err := asResponse(workflowToolHandler("OrderWorkflow", workflow, &mockTemporalClient{}, &config.Config{})(args))
+		require.NoError(t, err)
+		require.Len(t, resp.Content, 1)
+		require.Equal(t, "Error: Missing required parameters for workflow OrderWorkflow: order_id, customer_id",
+			resp.Content[0].TextContent.Text)
+	})
+
+	t.Run("in the response envelope", func(t *testing.T) {
+		resp, err := withEnvelope(0, workflowToolHandler("OrderWorkflow", workflow, &mockTemporalClient{}, &config.Config{}))(args)
+		require.NoError(t, err)
+		var envelope toolEnvelope
+		require.NoError(t, json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &envelope))
+		require.False(t, envelope.OK)
+		require.Empty(t, envelope.Warnings)
+		require.Equal(t, "Error: Missing required parameters for workflow OrderWorkflow: order_id, customer_id", envelope.Error.Message)
+		require.Len(t, envelope.Error.Fields, 2)
+		require.Equal(t, paramError{Param: "customer_id", Reason: paramErrorEmpty}, envelope.Error.Fields[1])
+	})
+
+	t.Run("input schema violations", func(t *testing.T) {
+		require.Equal(t, []paramError{
+			{Param: "quantity", Reason: paramErrorConstraint, Message: "0 is less than the minimum 1"},
+			{Param: "order_id", Reason: paramErrorMissing},
+			{Param: "coupon", Reason: paramErrorUnexpected},
+			{Param: "items", Reason: paramErrorConstraint, Message: "expected string, got number"},
+		}, schemaParamErrors([]schema.Violation{
+			{Path: []string{"quantity"}, Keyword: "minimum", Message: "0 is less than the minimum 1"},
+			{Keyword: "required", Property: "order_id", Message: `missing required property "order_id"`},
+			{Keyword: "additionalProperties", Property: "coupon", Message: `unexpected property "coupon"`},
+			{Path: []string{"items", "[0]"}, Keyword: "type", Message: "expected string, got number"},
+		}))
+	})
+
+	t.Run("transform failures", func(t *testing.T) {
+		workflow := testWorkflow()
+		workflow.Input.Transforms = map[string][]string{"order_id": {"toEpoch"}}
+		_, err := workflowToolHandler("OrderWorkflow", workflow, &mockTemporalClient{}, &config.Config{})(WorkflowParams{Params: map[string]string{"order_id": "soon"}})
+		errs := paramErrorsOf(t, err)
+		require.Len(t, errs, 1)
+		require.Equal(t, "order_id", errs[0].Param)
+		require.Equal(t, paramErrorConstraint, errs[0].Reason)
+		require.Contains(t, errs[0].Message, "toEpoch: ")
+	})
+
+	t.Run("strict mode", func(t *testing.T) {
+		cfg := &config.Config{StrictParams: true}
+		_, err := workflowToolHandler("OrderWorkflow", workflow, &mockTemporalClient{}, cfg)(WorkflowParams{Params: map[string]string{"coupon": "X"}})
+		require.Equal(t, []paramError{{Param: "coupon", Reason: paramErrorUnexpected}}, paramErrorsOf(t, err))
+	})
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"strings"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+)
+
+// redactedValue replaces the values of redacted params in logs
+const redactedValue = "[REDACTED]"
+
+// logWorkflowParams logs a workflow execution's params when cfg.LogParams is set. Values of keys listed in
+// cfg.RedactParamKeys (case-insensitive) are masked. Params may hold secrets, so without a redaction list nothing is
+// logged unless cfg.LogParamsUnredacted explicitly acknowledges that.
+func logWorkflowParams(cfg *config.Config, name string, params map[string]string) {
+	if cfg == nil || !cfg.LogParams {
+		return
+	}
+	if len(cfg.RedactParamKeys) == 0 && !cfg.LogParamsUnredacted {
+		log.Printf("Not logging params of workflow %s: logParams is set but redactParamKeys is empty (set "+
+			"logParamsUnredacted to log them anyway)", name)
+		return
+	}
+
+	redacted := make(map[string]string, len(params))
+	for key, value := range params {
+		redacted[key] = value
+		for _, redactKey := range cfg.RedactParamKeys {
+			if strings.EqualFold(key, redactKey) {
+				redacted[key] = redactedValue
+				break
+			}
+		}
+	}
+
+	// json.Marshal sorts the keys, so log lines are stable
+	bytes, err := json.Marshal(redacted)
+	if err != nil {
+		log.Printf("Could not log params of workflow %s: %v", name, err)
+		return
+	}
+	log.Printf("Params of workflow %s: %s", name, bytes)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+// captureLog returns what fn logs
+func captureLog(t *testing.T, fn func()) string {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+	fn()
+	return buf.String()
+}
+
+func TestLogWorkflowParams(t *testing.T) {
+	params := map[string]string{"order_id": "42", "API_KEY": "s3cret", "password": "hunter2"}
+
+	t.Run("redacted keys are masked", func(t *testing.T) {
+		cfg := &config.Config{LogParams: true, RedactParamKeys: []string{"api_key", "Password"}}
+		output := captureLog(t, func() { logWorkflowParams(cfg, "OrderWorkflow", params) })
+		require.Contains(t, output, `Params of workflow OrderWorkflow: {"API_KEY":"[REDACTED]","order_id":"42","password":"[REDACTED]"}`)
+		require.NotContains(t, output, "s3cret")
+		require.NotContains(t, output, "hunter2")
+	})
+
+	t.Run("nothing is logged without a redaction list", func(t *testing.T) {
+		cfg := &config.Config{LogParams: true}
+		output := captureLog(t, func() { logWorkflowParams(cfg, "OrderWorkflow", params) })
+		require.Contains(t, output, "Not logging params of workflow OrderWorkflow")
+		require.NotContains(t, output, "s3cret")
+	})
+
+	t.Run("unredacted logging must be acknowledged", func(t *testing.T) {
+		cfg := &config.Config{LogParams: true, LogParamsUnredacted: true}
+		output := captureLog(t, func() { logWorkflowParams(cfg, "OrderWorkflow", params) })
+		require.Contains(t, output, `"API_KEY":"s3cret"`)
+	})
+
+	t.Run("off by default", func(t *testing.T) {
+		output := captureLog(t, func() { logWorkflowParams(&config.Config{}, "OrderWorkflow", params) })
+		require.Empty(t, output)
+	})
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+)
+
+// paramTransform normalizes a param value before it's validated, hashed into the workflow ID and sent to the workflow
+type paramTransform func(value string) (string, error)
+
+// paramTransforms maps the names usable in an input's transforms to their implementation
+var paramTransforms = map[string]paramTransform{
+	"trim":    func(value string) (string, error) { return strings.TrimSpace(value), nil },
+	"lower":   func(value string) (string, error) { return strings.ToLower(value), nil },
+	"upper":   func(value string) (string, error) { return strings.ToUpper(value), nil },
+	"toEpoch": toEpoch,
+}
+
+// epochLayouts are the date/time formats toEpoch accepts. Layouts without a zone are read as UTC.
+var epochLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+// toEpoch converts a date or date-time to Unix seconds. A value that already is an integer is returned as-is.
+func toEpoch(value string) (string, error) {
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return value, nil
+	}
+	for _, layout := range epochLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return strconv.FormatInt(t.Unix(), 10), nil
+		}
+	}
+	return "", fmt.Errorf("%q is not a date (expected e.g. 2025-05-01 or 2025-05-01T12:00:00Z)", value)
+}
+
+// validateParamTransforms checks that every transform named in the input definition exists
+func validateParamTransforms(input config.ParameterDef) error {
+	for _, field := range slices.Sorted(maps.Keys(input.Transforms)) {
+		for _, name := range input.Transforms[field] {
+			if _, ok := paramTransforms[name]; !ok {
+				return fmt.Errorf("unknown transform %q for param %s", name, field)
+			}
+		}
+	}
+	return nil
+}
+
+// transformParams applies the input definition's transforms to params, in place. Params that weren't provided are
+// paramTransformError is a param value a transform rejected
+type paramTransformError struct {
+	param     string
+	transform string
+	err       error
+}
+
+func (e *paramTransformError) Error() string {
+	return fmt.Sprintf("param %s: %s: %v", e.param, e.transform, e.err)
+}
+
+func (e *paramTransformError) Unwrap() error {
+	return e.err
+}
+
+// left alone.
+func transformParams(input config.ParameterDef, params map[string]string) error {
+	for _, field := range slices.Sorted(maps.Keys(input.Transforms)) {
+		value, ok := params[field]
+		if !ok {
+			continue
+		}
+		for _, name := range input.Transforms[field] {
+			transform, ok := paramTransforms[name]
+			if !ok {
+				return fmt.Errorf("unknown transform %q for param %s", name, field)
+			}
+			transformed, err := transform(value)
+			if err != nil {
+				return &paramTransformError{param: field, transform: name, err: err}
+			}
+			value = transformed
+		}
+		params[field] = value
+	}
+	return nil
+}
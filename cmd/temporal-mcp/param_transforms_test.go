@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParamTransforms(t *testing.T) {
+	tests := map[string]struct {
+		transforms []string
+		value      string
+		expected   string
+		err        string
+	}{
+		"trim":               {transforms: []string{"trim"}, value: "  abc \n", expected: "abc"},
+		"lower":              {transforms: []string{"lower"}, value: "ABC-def", expected: "abc-def"},
+		"upper":              {transforms: []string{"upper"}, value: "ABC-def", expected: "ABC-DEF"},
+		"toEpoch date":       {transforms: []string{"toEpoch"}, value: "2025-05-01", expected: "1746057600"},
+		"toEpoch RFC 3339":   {transforms: []string{"toEpoch"}, value: "2025-05-01T12:00:00+02:00", expected: "1746093600"},
+		"toEpoch local time": {transforms: []string{"toEpoch"}, value: "2025-05-01 12:00:00", expected: "1746100800"},
+		"toEpoch epoch":      {transforms: []string{"toEpoch"}, value: "1746057600", expected: "1746057600"},
+		"toEpoch invalid":    {transforms: []string{"toEpoch"}, value: "next tuesday", err: `param when: toEpoch: "next tuesday" is not a date`},
+		"chained in order":   {transforms: []string{"trim", "toEpoch"}, value: " 2025-05-01 ", expected: "1746057600"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			input := config.ParameterDef{Transforms: map[string][]string{"when": tc.transforms}}
+			params := map[string]string{"when": tc.value, "other": " untouched "}
+			err := transformParams(input, params)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, params["when"])
+			require.Equal(t, " untouched ", params["other"])
+		})
+	}
+
+	require.ErrorContains(t, validateParamTransforms(config.ParameterDef{Transforms: map[string][]string{"x": {"reverse"}}}),
+		`unknown transform "reverse" for param x`)
+}
+
+func TestParamTransformsApplyBeforeHashing(t *testing.T) {
+	workflow := testWorkflow()
+	workflow.WorkflowIDRecipe = "order_{{ hash .order_id }}"
+	workflow.Input.Transforms = map[string][]string{"order_id": {"trim", "lower"}}
+
+	var ids []string
+	for _, orderID := range []string{"abc-42", "  ABC-42", "Abc-42 "} {
+		mockClient := &mockTemporalClient{runResult: "ok"}
+		_, err := workflowToolHandler("OrderWorkflow", workflow, mockClient, nil)(WorkflowParams{Params: map[string]string{"order_id": orderID}})
+		require.NoError(t, err)
+		require.Equal(t, []any{ParamsMap{"order_id": "abc-42"}}, mockClient.lastWorkflowArgs)
+		ids = append(ids, mockClient.lastStartOptions.ID)
+	}
+	require.Equal(t, ids[0], ids[1])
+	require.Equal(t, ids[0], ids[2])
+
+	t.Run("blank required param is still missing", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runResult: "ok"}
+		resp, err := asResponse(workflowToolHandler("OrderWorkflow", workflow, mockClient, nil)(WorkflowParams{Params: map[string]string{"order_id": "   "}}))
+		require.NoError(t, err)
+		require.Contains(t, resp.Content[0].TextContent.Text, "Error: Missing required parameters for workflow OrderWorkflow: order_id")
+	})
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflow/v1"
+	"go.temporal.io/sdk/client"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetPendingActivitiesParams are the arguments of the GetPendingActivities tool
+type GetPendingActivitiesParams struct {
+	NamespaceParam
+
+	WorkflowID string `json:"workflowId"`
+	RunID      string `json:"runId"`
+}
+
+// pendingActivitiesResult is the JSON returned by the GetPendingActivities tool
+type pendingActivitiesResult struct {
+	WorkflowID        string                  `json:"workflowId"`
+	RunID             string                  `json:"runId"`
+	Status            string                  `json:"status"`
+	PendingActivities []pendingActivityResult `json:"pendingActivities"`
+}
+
+// pendingActivityResult is one activity the workflow is waiting on. MaximumAttempts is 0 when retries are unlimited.
+type pendingActivityResult struct {
+	ActivityID              string     `json:"activityId"`
+	ActivityType            string     `json:"activityType"`
+	State                   string     `json:"state"`
+	Attempt                 int32      `json:"attempt"`
+	MaximumAttempts         int32      `json:"maximumAttempts"`
+	LastFailure             string     `json:"lastFailure,omitempty"`
+	LastWorkerIdentity      string     `json:"lastWorkerIdentity,omitempty"`
+	ScheduledTime           *time.Time `json:"scheduledTime,omitempty"`
+	LastStartedTime         *time.Time `json:"lastStartedTime,omitempty"`
+	LastHeartbeatTime       *time.Time `json:"lastHeartbeatTime,omitempty"`
+	NextAttemptScheduleTime *time.Time `json:"nextAttemptScheduleTime,omitempty"`
+	Paused                  bool       `json:"paused,omitempty"`
+}
+
+// registerGetPendingActivitiesTool registers a tool that lists the activities a workflow is waiting on
+func registerGetPendingActivitiesTool(server *mcp.Server, tempClient client.Client, clients namespaceClients, cfg *config.Config) error {
+	desc := "Lists the activities a workflow is currently waiting on - type, state, attempt, last failure and when the next " +
+		"retry is scheduled - which shows what a stuck workflow is retrying far more directly than its full history. " +
+		"runId is optional - if omitted, this tool describes the " + runSelector(cfg) + " run of the given workflowId"
+
+	return registerTool(server, cfg, "GetPendingActivities", desc+namespaceDescription(cfg), namespacedHandler(tempClient, clients, cfg, getPendingActivitiesHandler))
+}
+
+func getPendingActivitiesHandler(tempClient client.Client, cfg *config.Config) func(args GetPendingActivitiesParams) (*mcp.ToolResponse, error) {
+	return func(args GetPendingActivitiesParams) (*mcp.ToolResponse, error) {
+		// Check if Temporal client is available
+		if tempClient == nil {
+			log.Printf("Error: Temporal client is not available for getting pending activities")
+			return degradedResponse("Error: Temporal client is not available for getting pending activities")
+		}
+
+		runID, err := resolveRun(context.Background(), tempClient, cfg, args.WorkflowID, args.RunID)
+		if err != nil {
+			msg := fmt.Sprintf("Error: %v", err)
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		resp, err := tempClient.DescribeWorkflowExecution(context.Background(), args.WorkflowID, runID)
+		if err != nil {
+			msg := fmt.Sprintf("Error: Failed to describe workflow %s: %v", args.WorkflowID, err)
+			var notFound *serviceerror.NotFound
+			if errors.As(err, &notFound) {
+				msg = fmt.Sprintf("Error: Workflow %s not found", args.WorkflowID)
+			}
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		info := resp.GetWorkflowExecutionInfo()
+		result := pendingActivitiesResult{
+			WorkflowID:        info.GetExecution().GetWorkflowId(),
+			RunID:             info.GetExecution().GetRunId(),
+			Status:            info.GetStatus().String(),
+			PendingActivities: make([]pendingActivityResult, 0, len(resp.GetPendingActivities())),
+		}
+		for _, activity := range resp.GetPendingActivities() {
+			result.PendingActivities = append(result.PendingActivities, newPendingActivityResult(activity))
+		}
+
+		bytes, err := json.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResponse(mcp.NewTextContent(string(bytes))), nil
+	}
+}
+
+func newPendingActivityResult(activity *workflow.PendingActivityInfo) pendingActivityResult {
+	return pendingActivityResult{
+		ActivityID:              activity.GetActivityId(),
+		ActivityType:            activity.GetActivityType().GetName(),
+		State:                   activity.GetState().String(),
+		Attempt:                 activity.GetAttempt(),
+		MaximumAttempts:         activity.GetMaximumAttempts(),
+		LastFailure:             activity.GetLastFailure().GetMessage(),
+		LastWorkerIdentity:      activity.GetLastWorkerIdentity(),
+		ScheduledTime:           optionalTime(activity.GetScheduledTime()),
+		LastStartedTime:         optionalTime(activity.GetLastStartedTime()),
+		LastHeartbeatTime:       optionalTime(activity.GetLastHeartbeatTime()),
+		NextAttemptScheduleTime: optionalTime(activity.GetNextAttemptScheduleTime()),
+		Paused:                  activity.GetPaused(),
+	}
+}
+
+// optionalTime converts a protobuf timestamp, which is nil when unset, for JSON fields that are omitted when empty
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/common/v1"
+	temporal_enums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/failure/v1"
+	"go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGetPendingActivities(t *testing.T) {
+	nextAttempt := time.Date(2025, 3, 1, 12, 0, 30, 0, time.UTC)
+	mockClient := &mockTemporalClient{describeResponse: &workflowservice.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &workflow.WorkflowExecutionInfo{
+			Execution: &common.WorkflowExecution{WorkflowId: "order_1", RunId: "run-1"},
+			Status:    temporal_enums.WORKFLOW_EXECUTION_STATUS_RUNNING,
+		},
+		PendingActivities: []*workflow.PendingActivityInfo{{
+			ActivityId:              "5",
+			ActivityType:            &common.ActivityType{Name: "ChargeCard"},
+			State:                   temporal_enums.PENDING_ACTIVITY_STATE_SCHEDULED,
+			Attempt:                 4,
+			LastFailure:             &failure.Failure{Message: "payment gateway timeout"},
+			LastWorkerIdentity:      "worker-1@host",
+			NextAttemptScheduleTime: timestamppb.New(nextAttempt),
+		}},
+	}}
+
+	resp, err := getPendingActivitiesHandler(mockClient, nil)(GetPendingActivitiesParams{WorkflowID: "order_1"})
+	require.NoError(t, err)
+
+	var result pendingActivitiesResult
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &result))
+	require.Equal(t, pendingActivitiesResult{
+		WorkflowID: "order_1",
+		RunID:      "run-1",
+		Status:     "Running",
+		PendingActivities: []pendingActivityResult{{
+			ActivityID:              "5",
+			ActivityType:            "ChargeCard",
+			State:                   "Scheduled",
+			Attempt:                 4,
+			LastFailure:             "payment gateway timeout",
+			LastWorkerIdentity:      "worker-1@host",
+			NextAttemptScheduleTime: &nextAttempt,
+		}},
+	}, result)
+	require.Equal(t, "order_1", mockClient.lastDescribeID)
+
+	t.Run("no pending activities", func(t *testing.T) {
+		mockClient := &mockTemporalClient{describeResponse: &workflowservice.DescribeWorkflowExecutionResponse{
+			WorkflowExecutionInfo: &workflow.WorkflowExecutionInfo{Execution: &common.WorkflowExecution{WorkflowId: "order_1"}},
+		}}
+		resp, err := getPendingActivitiesHandler(mockClient, nil)(GetPendingActivitiesParams{WorkflowID: "order_1"})
+		require.NoError(t, err)
+		require.Contains(t, resp.Content[0].TextContent.Text, `"pendingActivities":[]`)
+	})
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"go.temporal.io/sdk/client"
+)
+
+// version identifies the build; override with -ldflags "-X main.version=..."
+var version = "dev"
+
+// pingHealthCheckTimeout bounds how long Ping waits on the Temporal health check
+const pingHealthCheckTimeout = 5 * time.Second
+
+// PingParams are the (empty) arguments of the Ping tool
+type PingParams struct{}
+
+// pingResult is the JSON document returned by the Ping tool
+type pingResult struct {
+	Status            string  `json:"status"`
+	Version           string  `json:"version"`
+	UptimeSeconds     float64 `json:"uptimeSeconds"`
+	HostPort          string  `json:"hostPort"`
+	Namespace         string  `json:"namespace"`
+	TemporalReachable bool    `json:"temporalReachable"`
+	TemporalError     string  `json:"temporalError,omitempty"`
+}
+
+// registerPingTool registers a tool reporting server health in-protocol, for clients that can't reach an HTTP health
+// endpoint (e.g. over stdio)
+func registerPingTool(server *mcp.Server, cfg *config.Config, tempClient client.Client, startTime time.Time) error {
+	desc := "Reports the health of this MCP server: version, uptime, the configured Temporal namespace, and whether Temporal is reachable"
+
+	return registerTool(server, cfg, "Ping", desc, pingHandler(cfg, tempClient, startTime))
+}
+
+func pingHandler(cfg *config.Config, tempClient client.Client, startTime time.Time) func(args PingParams) (*mcp.ToolResponse, error) {
+	return func(args PingParams) (*mcp.ToolResponse, error) {
+		result := pingResult{
+			Status:        "ok",
+			Version:       version,
+			UptimeSeconds: time.Since(startTime).Seconds(),
+			HostPort:      cfg.Temporal.HostPort,
+			Namespace:     cfg.Temporal.Namespace,
+		}
+
+		if tempClient == nil {
+			result.Status = "degraded"
+			result.TemporalError = "Temporal client is not available"
+		} else {
+			ctx, cancel := context.WithTimeout(context.Background(), pingHealthCheckTimeout)
+			defer cancel()
+			if _, err := tempClient.CheckHealth(ctx, &client.CheckHealthRequest{}); err != nil {
+				result.Status = "degraded"
+				result.TemporalError = err.Error()
+			} else {
+				result.TemporalReachable = true
+			}
+		}
+
+		bytes, err := json.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResponse(mcp.NewTextContent(string(bytes))), nil
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+)
+
+func TestPing(t *testing.T) {
+	cfg := &config.Config{
+		Temporal: config.TemporalConfig{HostPort: "localhost:7233", Namespace: "default"},
+	}
+	startTime := time.Now().Add(-time.Minute)
+
+	tests := map[string]struct {
+		client            *mockTemporalClient
+		expectedStatus    string
+		expectedReachable bool
+	}{
+		"healthy":     {client: &mockTemporalClient{}, expectedStatus: "ok", expectedReachable: true},
+		"unreachable": {client: &mockTemporalClient{healthErr: errors.New("connection refused")}, expectedStatus: "degraded"},
+		"no client":   {client: nil, expectedStatus: "degraded"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			handler := pingHandler(cfg, nil, startTime)
+			if tc.client != nil {
+				handler = pingHandler(cfg, tc.client, startTime)
+			}
+
+			resp, err := handler(PingParams{})
+			require.NoError(t, err)
+
+			var result pingResult
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &result))
+			require.Equal(t, tc.expectedStatus, result.Status)
+			require.Equal(t, tc.expectedReachable, result.TemporalReachable)
+			require.Equal(t, tc.expectedReachable, result.TemporalError == "")
+			require.GreaterOrEqual(t, result.UptimeSeconds, 60.0)
+			require.Equal(t, "default", result.Namespace)
+			require.Equal(t, version, result.Version)
+		})
+	}
+}
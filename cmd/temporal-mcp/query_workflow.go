@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+)
+
+// QueryWorkflowParams are the arguments of the QueryWorkflow tool
+type QueryWorkflowParams struct {
+	NamespaceParam
+
+	WorkflowID string `json:"workflowId"`
+	RunID      string `json:"runId"`
+	QueryType  string `json:"queryType"`
+	Args       []any  `json:"args,omitempty"`
+}
+
+// registerQueryWorkflowTool registers a tool that runs a query handler of a workflow
+func registerQueryWorkflowTool(server *mcp.Server, tempClient client.Client, clients namespaceClients, cfg *config.Config) error {
+	desc := "Runs a query handler of a workflow (queryType, with optional args) and returns its result as JSON - a cheap way to " +
+		"inspect the state of an in-flight workflow without reading its history. The workflow's worker must be running. " +
+		"runId is optional - if omitted, this tool queries the " + runSelector(cfg) + " run of the given workflowId"
+
+	return registerTool(server, cfg, "QueryWorkflow", desc+namespaceDescription(cfg), namespacedHandler(tempClient, clients, cfg, queryWorkflowHandler))
+}
+
+func queryWorkflowHandler(tempClient client.Client, cfg *config.Config) func(args QueryWorkflowParams) (*mcp.ToolResponse, error) {
+	return func(args QueryWorkflowParams) (*mcp.ToolResponse, error) {
+		// Check if Temporal client is available
+		if tempClient == nil {
+			log.Printf("Error: Temporal client is not available for querying workflows")
+			return degradedResponse("Error: Temporal client is not available for querying workflows")
+		}
+
+		if args.QueryType == "" {
+			return errorResponse("Error: queryType is required")
+		}
+
+		runID, err := resolveRun(context.Background(), tempClient, cfg, args.WorkflowID, args.RunID)
+		if err != nil {
+			msg := fmt.Sprintf("Error: %v", err)
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		value, err := tempClient.QueryWorkflow(context.Background(), args.WorkflowID, runID, args.QueryType, args.Args...)
+		if err != nil {
+			msg := fmt.Sprintf("Error: Query %s of workflow %s failed: %v", args.QueryType, args.WorkflowID, err)
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		result, err := queryResultJSON(value)
+		if err != nil {
+			msg := fmt.Sprintf("Error: Could not decode the result of query %s of workflow %s: %v", args.QueryType, args.WorkflowID, err)
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		// Structured results are returned as JSON, so the response envelope embeds them as data rather than as a string
+		return mcp.NewToolResponse(mcp.NewTextContent(string(result))), nil
+	}
+}
+
+// queryResultJSON decodes a query result into JSON, keeping JSON payloads byte for byte (so large numbers keep their
+// precision) and converting other payloads via their decoded value
+func queryResultJSON(value converter.EncodedValue) (json.RawMessage, error) {
+	if value == nil || !value.HasValue() {
+		return json.RawMessage("null"), nil
+	}
+	var raw json.RawMessage
+	if err := value.Get(&raw); err == nil && json.Valid(raw) {
+		return raw, nil
+	}
+	var decoded any
+	if err := value.Get(&decoded); err != nil {
+		return nil, err
+	}
+	return json.Marshal(decoded)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryWorkflow(t *testing.T) {
+	type orderState struct {
+		Status string   `json:"status"`
+		Items  []string `json:"items"`
+		Total  int64    `json:"total"`
+	}
+	mockClient := &mockTemporalClient{queryResult: orderState{Status: "packing", Items: []string{"SKU-1"}, Total: 9007199254740993}}
+
+	args := QueryWorkflowParams{WorkflowID: "order_1", QueryType: "state", Args: []any{"verbose"}}
+	resp, err := queryWorkflowHandler(mockClient, nil)(args)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"status": "packing", "items": ["SKU-1"], "total": 9007199254740993}`, resp.Content[0].TextContent.Text)
+	require.Equal(t, "state", mockClient.lastQueryType)
+	require.Equal(t, []any{"verbose"}, mockClient.lastQueryArgs)
+
+	t.Run("struct results are objects in the response envelope", func(t *testing.T) {
+		resp, err := withEnvelope(0, queryWorkflowHandler(mockClient, &config.Config{}))(args)
+		require.NoError(t, err)
+		var envelope struct {
+			OK   bool            `json:"ok"`
+			Data json.RawMessage `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &envelope))
+		require.True(t, envelope.OK)
+		require.JSONEq(t, `{"status": "packing", "items": ["SKU-1"], "total": 9007199254740993}`, string(envelope.Data))
+	})
+
+	t.Run("binary results", func(t *testing.T) {
+		resp, err := queryWorkflowHandler(&mockTemporalClient{queryResult: []byte("raw")}, nil)(args)
+		require.NoError(t, err)
+		require.Equal(t, `"cmF3"`, resp.Content[0].TextContent.Text)
+	})
+
+	t.Run("queryType is required", func(t *testing.T) {
+		resp, err := asResponse(queryWorkflowHandler(mockClient, nil)(QueryWorkflowParams{WorkflowID: "order_1"}))
+		require.NoError(t, err)
+		require.Equal(t, "Error: queryType is required", resp.Content[0].TextContent.Text)
+	})
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+)
+
+// resultRedactor masks sensitive values in decoded workflow results, so secrets and PII aren't handed to the client
+type resultRedactor struct {
+	patterns []*regexp.Regexp
+	fields   [][]string
+}
+
+// newResultRedactor combines the global and the workflow's result redaction. cfg may be nil. It returns nil if there is
+// nothing to redact.
+func newResultRedactor(cfg *config.Config, workflow config.WorkflowDef) (*resultRedactor, error) {
+	var defs []config.RedactionDef
+	if cfg != nil {
+		defs = append(defs, cfg.ResultRedaction)
+	}
+	defs = append(defs, workflow.ResultRedaction)
+
+	redactor := &resultRedactor{}
+	for _, def := range defs {
+		for _, pattern := range def.Patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid resultRedaction pattern %q: %w", pattern, err)
+			}
+			redactor.patterns = append(redactor.patterns, re)
+		}
+		for _, field := range def.Fields {
+			if field == "" {
+				return nil, fmt.Errorf("invalid resultRedaction field: empty path")
+			}
+			redactor.fields = append(redactor.fields, strings.Split(field, "."))
+		}
+	}
+	if len(redactor.patterns) == 0 && len(redactor.fields) == 0 {
+		return nil, nil
+	}
+	return redactor, nil
+}
+
+// redact returns a copy of result with the configured fields replaced by redactedValue and pattern matches masked in
+// every remaining string. A nil redactor returns result unchanged.
+func (r *resultRedactor) redact(result interface{}) interface{} {
+	if r == nil {
+		return result
+	}
+	redacted := r.maskPatterns(result)
+	for _, path := range r.fields {
+		redacted = redactField(redacted, path)
+	}
+	return redacted
+}
+
+// maskPatterns copies value, masking pattern matches in its strings
+func (r *resultRedactor) maskPatterns(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		for _, re := range r.patterns {
+			v = re.ReplaceAllString(v, redactedValue)
+		}
+		return v
+	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			masked[key] = r.maskPatterns(item)
+		}
+		return masked
+	case []interface{}:
+		masked := make([]interface{}, len(v))
+		for i, item := range v {
+			masked[i] = r.maskPatterns(item)
+		}
+		return masked
+	default:
+		return value
+	}
+}
+
+// redactField replaces the value at path with redactedValue. A non-numeric segment applies to every element of an
+// array, so "customers.ssn" covers each customer. Paths that don't exist in the value are ignored. Maps and slices
+// along the path must already be copies, as maskPatterns makes them.
+func redactField(value interface{}, path []string) interface{} {
+	if len(path) == 0 {
+		return redactedValue
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if item, ok := v[path[0]]; ok {
+			v[path[0]] = redactField(item, path[1:])
+		}
+	case []interface{}:
+		if index, err := strconv.Atoi(path[0]); err == nil {
+			if index >= 0 && index < len(v) {
+				v[index] = redactField(v[index], path[1:])
+			}
+			return v
+		}
+		for i, item := range v {
+			v[i] = redactField(item, path)
+		}
+	}
+	return value
+}
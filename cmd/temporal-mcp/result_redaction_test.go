@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowToolRedactsResults(t *testing.T) {
+	cfg := &config.Config{ResultRedaction: config.RedactionDef{Patterns: []string{`\b\d{3}-\d{2}-\d{4}\b`}}}
+	workflow := testWorkflow()
+	workflow.ResultRedaction = config.RedactionDef{Fields: []string{"customers.email", "token"}}
+	args := WorkflowParams{Params: map[string]string{"order_id": "42"}}
+
+	t.Run("patterns and fields are masked", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runResult: map[string]any{
+			"note":  "customer SSN is 123-45-6789, call back",
+			"token": map[string]any{"value": "secret"},
+			"customers": []any{
+				map[string]any{"name": "Ada", "email": "ada@example.com", "ssn": "987-65-4321"},
+				map[string]any{"name": "Bob"},
+			},
+		}}
+		resp, err := workflowToolHandler("OrderWorkflow", workflow, mockClient, cfg)(args)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"note": "customer SSN is [REDACTED], call back",
+			"token": "[REDACTED]",
+			"customers": [
+				{"name": "Ada", "email": "[REDACTED]", "ssn": "[REDACTED]"},
+				{"name": "Bob"}
+			]
+		}`, resp.Content[0].TextContent.Text)
+	})
+
+	t.Run("string result", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runResult: "SSN 123-45-6789 on file"}
+		resp, err := workflowToolHandler("OrderWorkflow", workflow, mockClient, cfg)(args)
+		require.NoError(t, err)
+		require.Equal(t, "SSN [REDACTED] on file", resp.Content[0].TextContent.Text)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := newResultRedactor(&config.Config{ResultRedaction: config.RedactionDef{Patterns: []string{"("}}}, workflow)
+		require.Error(t, err)
+	})
+
+	t.Run("nothing configured", func(t *testing.T) {
+		redactor, err := newResultRedactor(nil, testWorkflow())
+		require.NoError(t, err)
+		require.Nil(t, redactor)
+	})
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+)
+
+// namespaceRetentionWarning returns a warning if the namespace keeps closed workflows for less than minRetention, or ""
+// if it keeps them long enough. Workflow tools dedupe calls by attaching to a completed workflow with the same ID, which
+// only works while that workflow is retained - after that, the "deduped" workflow silently runs again.
+func namespaceRetentionWarning(ctx context.Context, tempClient client.Client, namespace string, minRetention time.Duration) (string, error) {
+	resp, err := tempClient.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{Namespace: namespace})
+	if err != nil {
+		return "", fmt.Errorf("failed to describe namespace %s: %w", namespace, err)
+	}
+
+	retention := resp.GetConfig().GetWorkflowExecutionRetentionTtl().AsDuration()
+	if retention >= minRetention {
+		return "", nil
+	}
+	return fmt.Sprintf("WARNING: Namespace %s retains closed workflows for %v, less than the expected minimum of %v - workflow "+
+		"tool calls repeated after %v will run the workflow again instead of returning the earlier result",
+		namespace, retention, minRetention, retention), nil
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceRetentionWarning(t *testing.T) {
+	t.Run("short retention warns", func(t *testing.T) {
+		mockClient := &mockTemporalClient{namespaceRetention: 24 * time.Hour}
+		warning, err := namespaceRetentionWarning(context.Background(), mockClient, "default", 72*time.Hour)
+		require.NoError(t, err)
+		require.Contains(t, warning, "Namespace default retains closed workflows for 24h0m0s")
+		require.Contains(t, warning, "expected minimum of 72h0m0s")
+	})
+
+	t.Run("long enough retention", func(t *testing.T) {
+		mockClient := &mockTemporalClient{namespaceRetention: 7 * 24 * time.Hour}
+		warning, err := namespaceRetentionWarning(context.Background(), mockClient, "default", 72*time.Hour)
+		require.NoError(t, err)
+		require.Empty(t, warning)
+	})
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+	temporal_sdk "go.temporal.io/sdk/temporal"
+)
+
+// Retry defaults, used for any RetryOptions field left unset
+const (
+	defaultRetryInitialInterval    = 100 * time.Millisecond
+	defaultRetryMaximumInterval    = 10 * time.Second
+	defaultRetryMaximumAttempts    = 5
+	defaultRetryBackoffCoefficient = 2.0
+)
+
+// retryPolicy is the parsed form of config.RetryOptions
+type retryPolicy struct {
+	initialInterval    time.Duration
+	maximumInterval    time.Duration
+	maxAttempts        int
+	backoffCoefficient float64
+}
+
+// newRetryPolicy parses retry options, falling back to defaults for unset or invalid values
+func newRetryPolicy(opts config.RetryOptions) retryPolicy {
+	policy := retryPolicy{
+		initialInterval:    parseDurationOrDefault("retryOptions.initialInterval", opts.InitialInterval, defaultRetryInitialInterval),
+		maximumInterval:    parseDurationOrDefault("retryOptions.maximumInterval", opts.MaximumInterval, defaultRetryMaximumInterval),
+		maxAttempts:        opts.MaximumAttempts,
+		backoffCoefficient: opts.BackoffCoefficient,
+	}
+	if policy.maxAttempts <= 0 {
+		policy.maxAttempts = defaultRetryMaximumAttempts
+	}
+	if policy.backoffCoefficient < 1 {
+		policy.backoffCoefficient = defaultRetryBackoffCoefficient
+	}
+	return policy
+}
+
+// backoff returns the delay before the retry following the given (1-based) attempt
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.initialInterval)
+	for i := 1; i < attempt; i++ {
+		delay *= p.backoffCoefficient
+		if delay >= float64(p.maximumInterval) {
+			return p.maximumInterval
+		}
+	}
+	return time.Duration(delay)
+}
+
+// parseDurationOrDefault parses an optional duration setting, logging and using the default when it's invalid
+func parseDurationOrDefault(name string, value string, defaultValue time.Duration) time.Duration {
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("WARNING: Invalid %s %q - using default %v", name, value, defaultValue)
+		return defaultValue
+	}
+	return d
+}
+
+// workflowRetryPolicy converts a workflow's configured retry policy into the one Temporal applies to the execution.
+// Unlike the MCP's own retries, unset fields are left to the server defaults, and nil means no retries. LoadConfig
+// validates retry policies, so the error only concerns configs built without it.
+func workflowRetryPolicy(opts *config.RetryOptions) (*temporal_sdk.RetryPolicy, error) {
+	if opts == nil {
+		return nil, nil
+	}
+	policy := &temporal_sdk.RetryPolicy{
+		MaximumAttempts:    int32(opts.MaximumAttempts),
+		BackoffCoefficient: opts.BackoffCoefficient,
+	}
+	var err error
+	if opts.InitialInterval != "" {
+		if policy.InitialInterval, err = time.ParseDuration(opts.InitialInterval); err != nil {
+			return nil, fmt.Errorf("invalid retryPolicy.initialInterval %q: %w", opts.InitialInterval, err)
+		}
+	}
+	if opts.MaximumInterval != "" {
+		if policy.MaximumInterval, err = time.ParseDuration(opts.MaximumInterval); err != nil {
+			return nil, fmt.Errorf("invalid retryPolicy.maximumInterval %q: %w", opts.MaximumInterval, err)
+		}
+	}
+	return policy, nil
+}
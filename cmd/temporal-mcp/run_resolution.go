@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"go.temporal.io/sdk/client"
+)
+
+// runSelector returns the configured selector for calls that omit the run ID. cfg may be nil.
+func runSelector(cfg *config.Config) string {
+	if cfg == nil || cfg.DefaultRunSelector == "" {
+		return config.RunSelectorLatest
+	}
+	return cfg.DefaultRunSelector
+}
+
+// resolveRun returns the run ID a tool should use for workflowID. An explicit runID is used as-is. Otherwise the
+// configured selector decides: "latest" returns "", which Temporal resolves to the latest run itself, and "first" looks
+// up the first run of the workflow's chain of runs (continue-as-new, retries, cron). Only read-only tools use it:
+// tools that change a workflow (signal, cancel, terminate) default to the latest run, as the first has usually closed.
+func resolveRun(ctx context.Context, tempClient client.Client, cfg *config.Config, workflowID, runID string) (string, error) {
+	if runID != "" {
+		return runID, nil
+	}
+
+	switch selector := runSelector(cfg); selector {
+	case config.RunSelectorLatest:
+		return "", nil
+	case config.RunSelectorFirst:
+		resp, err := tempClient.DescribeWorkflowExecution(ctx, workflowID, "")
+		if err != nil {
+			return "", fmt.Errorf("could not find the first run of workflow %s: %w", workflowID, err)
+		}
+		firstRunID := resp.GetWorkflowExecutionInfo().GetFirstRunId()
+		if firstRunID == "" {
+			// Servers before 1.21 don't report the first run
+			log.Printf("Temporal did not report the first run of workflow %s - using the latest run", workflowID)
+		}
+		return firstRunID, nil
+	default:
+		return "", fmt.Errorf("unsupported defaultRunSelector %q", selector)
+	}
+}
+
+// runLabel names a run resolved by resolveRun in messages; "" is the latest run
+func runLabel(runID string) string {
+	if runID == "" {
+		return "latest run"
+	}
+	return "run " + runID
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+func TestToolsResolveEmptyRunIDIdentically(t *testing.T) {
+	newClient := func() *mockTemporalClient {
+		return &mockTemporalClient{
+			describeResponse: &workflowservice.DescribeWorkflowExecutionResponse{
+				WorkflowExecutionInfo: &workflow.WorkflowExecutionInfo{
+					Execution:  &common.WorkflowExecution{WorkflowId: "order_1", RunId: "run-latest"},
+					FirstRunId: "run-first",
+				},
+			},
+			historyEvents: testHistoryEvents(),
+			queryResult:   "goroutine 1 [running]:",
+		}
+	}
+
+	// Each tool, called without a run ID, returns the run ID it used with Temporal
+	tools := map[string]func(cfg *config.Config) string{
+		"GetWorkflowHistory": func(cfg *config.Config) string {
+			mockClient := newClient()
+			_, err := getWorkflowHistoryHandler(mockClient, cfg)(GetWorkflowHistoryParams{WorkflowID: "order_1"})
+			require.NoError(t, err)
+			return mockClient.lastHistoryRunID
+		},
+		"TailWorkflow": func(cfg *config.Config) string {
+			mockClient := newClient()
+			_, err := tailWorkflowHandler(mockClient, cfg)(TailWorkflowParams{WorkflowID: "order_1", TimeoutSeconds: 1})
+			require.NoError(t, err)
+			return mockClient.lastHistoryRunID
+		},
+		"GetWorkflowStackTrace": func(cfg *config.Config) string {
+			mockClient := newClient()
+			_, err := getWorkflowStackTraceHandler(mockClient, cfg)(GetWorkflowStackTraceParams{WorkflowID: "order_1"})
+			require.NoError(t, err)
+			return mockClient.lastQueryRunID
+		},
+		"GetWorkflowAttributes": func(cfg *config.Config) string {
+			mockClient := newClient()
+			_, err := getWorkflowAttributesHandler(mockClient, cfg)(GetWorkflowAttributesParams{WorkflowID: "order_1"})
+			require.NoError(t, err)
+			return mockClient.lastDescribeRun
+		},
+		"BatchDescribeWorkflows": func(cfg *config.Config) string {
+			mockClient := newClient()
+			_, err := batchDescribeWorkflowsHandler(mockClient, cfg)(BatchDescribeWorkflowsParams{Workflows: []WorkflowRef{{WorkflowID: "order_1"}}})
+			require.NoError(t, err)
+			return mockClient.lastDescribeRun
+		},
+	}
+
+	selectors := map[string]struct {
+		cfg      *config.Config
+		expected string
+	}{
+		"default is latest": {cfg: fastRetryConfig(), expected: ""},
+		"first":             {cfg: &config.Config{DefaultRunSelector: config.RunSelectorFirst}, expected: "run-first"},
+	}
+	for selectorName, selector := range selectors {
+		for toolName, runIDUsed := range tools {
+			t.Run(selectorName+"/"+toolName, func(t *testing.T) {
+				require.Equal(t, selector.expected, runIDUsed(selector.cfg))
+			})
+		}
+	}
+
+	t.Run("explicit run ID wins", func(t *testing.T) {
+		mockClient := newClient()
+		cfg := &config.Config{DefaultRunSelector: config.RunSelectorFirst}
+		_, err := getWorkflowStackTraceHandler(mockClient, cfg)(GetWorkflowStackTraceParams{WorkflowID: "order_1", RunID: "run-7"})
+		require.NoError(t, err)
+		require.Equal(t, "run-7", mockClient.lastQueryRunID)
+		require.Empty(t, mockClient.lastDescribeID, "an explicit run needs no lookup")
+	})
+
+	t.Run("unknown selector", func(t *testing.T) {
+		cfg := &config.Config{DefaultRunSelector: "oldest"}
+		resp, err := asResponse(getWorkflowStackTraceHandler(newClient(), cfg)(GetWorkflowStackTraceParams{WorkflowID: "order_1"}))
+		require.NoError(t, err)
+		require.Equal(t, `Error: unsupported defaultRunSelector "oldest"`, resp.Content[0].TextContent.Text)
+	})
+}
+
+func TestMutatingToolsUseTheLatestRun(t *testing.T) {
+	cfg := &config.Config{DefaultRunSelector: config.RunSelectorFirst}
+	mockClient := &mockTemporalClient{}
+
+	_, err := signalWorkflowHandler(mockClient, cfg)(SignalWorkflowParams{WorkflowID: "order_1", SignalName: "approve"})
+	require.NoError(t, err)
+	_, err = cancelWorkflowHandler(mockClient)(CancelWorkflowParams{WorkflowID: "order_1"})
+	require.NoError(t, err)
+	_, err = terminateWorkflowHandler(mockClient)(TerminateWorkflowParams{WorkflowID: "order_1", Reason: "stuck"})
+	require.NoError(t, err)
+
+	require.Empty(t, mockClient.lastSignalRunID)
+	require.Empty(t, mockClient.lastCancelRunID)
+	require.Empty(t, mockClient.lastTerminateRun)
+	require.Empty(t, mockClient.lastDescribeID, "the first run must not be looked up")
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+)
+
+// DescribeScheduleParams are the arguments of the DescribeSchedule tool
+type DescribeScheduleParams struct {
+	ScheduleID string `json:"scheduleId"`
+}
+
+// ScheduleNoteParams are the arguments of the PauseSchedule and UnpauseSchedule tools
+type ScheduleNoteParams struct {
+	ScheduleID string `json:"scheduleId"`
+	Note       string `json:"note,omitempty"`
+}
+
+// describeScheduleResult is the JSON returned by the DescribeSchedule tool
+type describeScheduleResult struct {
+	ScheduleID       string                  `json:"scheduleId"`
+	Paused           bool                    `json:"paused"`
+	Note             string                  `json:"note,omitempty"`
+	Spec             scheduleSpecResult      `json:"spec"`
+	Action           *scheduleActionResult   `json:"action,omitempty"`
+	NextActionTimes  []time.Time             `json:"nextActionTimes"`
+	RecentActions    []scheduleRecentAction  `json:"recentActions"`
+	RunningWorkflows []scheduleWorkflowRunID `json:"runningWorkflows"`
+	NumActions       int                     `json:"numActions"`
+}
+
+// scheduleSpecResult describes when a schedule fires. Durations are rendered as Go duration strings (e.g. "1h0m0s").
+type scheduleSpecResult struct {
+	CronExpressions []string                      `json:"cronExpressions,omitempty"`
+	Intervals       []scheduleIntervalResult      `json:"intervals,omitempty"`
+	Calendars       []client.ScheduleCalendarSpec `json:"calendars,omitempty"`
+	TimeZone        string                        `json:"timeZone,omitempty"`
+	Jitter          string                        `json:"jitter,omitempty"`
+	StartAt         *time.Time                    `json:"startAt,omitempty"`
+	EndAt           *time.Time                    `json:"endAt,omitempty"`
+}
+
+type scheduleIntervalResult struct {
+	Every  string `json:"every"`
+	Offset string `json:"offset,omitempty"`
+}
+
+// scheduleActionResult describes the workflow a schedule starts
+type scheduleActionResult struct {
+	WorkflowType string `json:"workflowType"`
+	WorkflowID   string `json:"workflowId"`
+	TaskQueue    string `json:"taskQueue"`
+}
+
+type scheduleRecentAction struct {
+	ScheduleTime time.Time `json:"scheduleTime"`
+	ActualTime   time.Time `json:"actualTime"`
+	WorkflowID   string    `json:"workflowId,omitempty"`
+	RunID        string    `json:"runId,omitempty"`
+}
+
+type scheduleWorkflowRunID struct {
+	WorkflowID string `json:"workflowId"`
+	RunID      string `json:"runId"`
+}
+
+// registerDescribeScheduleTool registers a tool that describes a schedule
+func registerDescribeScheduleTool(server *mcp.Server, tempClient client.Client, cfg *config.Config) error {
+	desc := "Describes a Temporal schedule: when it fires (cron expressions, intervals, calendars), the workflow it starts, " +
+		"its next action times, recent actions, and whether it is paused"
+
+	return registerTool(server, cfg, "DescribeSchedule", desc, describeScheduleHandler(tempClient))
+}
+
+func describeScheduleHandler(tempClient client.Client) func(args DescribeScheduleParams) (*mcp.ToolResponse, error) {
+	return func(args DescribeScheduleParams) (*mcp.ToolResponse, error) {
+		// Check if Temporal client is available
+		if tempClient == nil {
+			log.Printf("Error: Temporal client is not available for describing schedules")
+			return degradedResponse("Error: Temporal client is not available for describing schedules")
+		}
+
+		ctx := context.Background()
+		description, err := tempClient.ScheduleClient().GetHandle(ctx, args.ScheduleID).Describe(ctx)
+		if err != nil {
+			msg := scheduleErrorMessage("describe", args.ScheduleID, err)
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		bytes, err := json.Marshal(newDescribeScheduleResult(args.ScheduleID, description))
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResponse(mcp.NewTextContent(string(bytes))), nil
+	}
+}
+
+// registerPauseScheduleTools registers the PauseSchedule and UnpauseSchedule tools
+func registerPauseScheduleTools(server *mcp.Server, tempClient client.Client, cfg *config.Config) error {
+	pauseDesc := "Pauses a Temporal schedule so it stops starting workflows until unpaused. Workflows it already started keep " +
+		"running. The optional note is recorded on the schedule - say why it was paused"
+	if err := registerTool(server, cfg, "PauseSchedule", pauseDesc, pauseScheduleHandler(tempClient, true)); err != nil {
+		return err
+	}
+
+	unpauseDesc := "Unpauses a paused Temporal schedule so it resumes starting workflows. The optional note is recorded on the schedule"
+	return registerTool(server, cfg, "UnpauseSchedule", unpauseDesc, pauseScheduleHandler(tempClient, false))
+}
+
+// pauseScheduleHandler pauses (pause == true) or unpauses a schedule
+func pauseScheduleHandler(tempClient client.Client, pause bool) func(args ScheduleNoteParams) (*mcp.ToolResponse, error) {
+	operation, past := "unpause", "unpaused"
+	if pause {
+		operation, past = "pause", "paused"
+	}
+
+	return func(args ScheduleNoteParams) (*mcp.ToolResponse, error) {
+		// Check if Temporal client is available
+		if tempClient == nil {
+			log.Printf("Error: Temporal client is not available for managing schedules")
+			return degradedResponse("Error: Temporal client is not available for managing schedules")
+		}
+
+		ctx := context.Background()
+		handle := tempClient.ScheduleClient().GetHandle(ctx, args.ScheduleID)
+		var err error
+		if pause {
+			err = handle.Pause(ctx, client.SchedulePauseOptions{Note: args.Note})
+		} else {
+			err = handle.Unpause(ctx, client.ScheduleUnpauseOptions{Note: args.Note})
+		}
+		if err != nil {
+			msg := scheduleErrorMessage(operation, args.ScheduleID, err)
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		log.Printf("Schedule %s %s (note: %q)", args.ScheduleID, past, args.Note)
+		return mcp.NewToolResponse(mcp.NewTextContent(fmt.Sprintf("Schedule %s %s", args.ScheduleID, past))), nil
+	}
+}
+
+// newDescribeScheduleResult flattens an SDK schedule description into the tool's result
+func newDescribeScheduleResult(scheduleID string, description *client.ScheduleDescription) describeScheduleResult {
+	result := describeScheduleResult{
+		ScheduleID:       scheduleID,
+		NextActionTimes:  description.Info.NextActionTimes,
+		RecentActions:    []scheduleRecentAction{},
+		RunningWorkflows: []scheduleWorkflowRunID{},
+		NumActions:       description.Info.NumActions,
+	}
+
+	if state := description.Schedule.State; state != nil {
+		result.Paused = state.Paused
+		result.Note = state.Note
+	}
+
+	if spec := description.Schedule.Spec; spec != nil {
+		result.Spec = scheduleSpecResult{
+			CronExpressions: spec.CronExpressions,
+			Calendars:       spec.Calendars,
+			TimeZone:        spec.TimeZoneName,
+		}
+		for _, interval := range spec.Intervals {
+			i := scheduleIntervalResult{Every: interval.Every.String()}
+			if interval.Offset != 0 {
+				i.Offset = interval.Offset.String()
+			}
+			result.Spec.Intervals = append(result.Spec.Intervals, i)
+		}
+		if spec.Jitter != 0 {
+			result.Spec.Jitter = spec.Jitter.String()
+		}
+		if !spec.StartAt.IsZero() {
+			result.Spec.StartAt = &spec.StartAt
+		}
+		if !spec.EndAt.IsZero() {
+			result.Spec.EndAt = &spec.EndAt
+		}
+	}
+
+	if action, ok := description.Schedule.Action.(*client.ScheduleWorkflowAction); ok {
+		result.Action = &scheduleActionResult{
+			WorkflowType: fmt.Sprint(action.Workflow),
+			WorkflowID:   action.ID,
+			TaskQueue:    action.TaskQueue,
+		}
+	}
+
+	for _, action := range description.Info.RecentActions {
+		recent := scheduleRecentAction{ScheduleTime: action.ScheduleTime, ActualTime: action.ActualTime}
+		if started := action.StartWorkflowResult; started != nil {
+			recent.WorkflowID = started.WorkflowID
+			recent.RunID = started.FirstExecutionRunID
+		}
+		result.RecentActions = append(result.RecentActions, recent)
+	}
+
+	for _, running := range description.Info.RunningWorkflows {
+		result.RunningWorkflows = append(result.RunningWorkflows, scheduleWorkflowRunID{
+			WorkflowID: running.WorkflowID,
+			RunID:      running.FirstExecutionRunID,
+		})
+	}
+
+	return result
+}
+
+// scheduleErrorMessage renders a failed schedule operation, calling out schedules that don't exist
+func scheduleErrorMessage(operation, scheduleID string, err error) string {
+	var notFound *serviceerror.NotFound
+	if errors.As(err, &notFound) {
+		return fmt.Sprintf("Error: Schedule %s not found", scheduleID)
+	}
+	return fmt.Sprintf("Error: Failed to %s schedule %s: %v", operation, scheduleID, err)
+}
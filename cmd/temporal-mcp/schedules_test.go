@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+)
+
+func TestDescribeSchedule(t *testing.T) {
+	next := time.Date(2025, 1, 2, 3, 0, 0, 0, time.UTC)
+	ran := time.Date(2025, 1, 2, 2, 0, 0, 0, time.UTC)
+
+	t.Run("returns the description", func(t *testing.T) {
+		mockClient := &mockTemporalClient{scheduleDescription: &client.ScheduleDescription{
+			Schedule: client.Schedule{
+				Action: &client.ScheduleWorkflowAction{ID: "nightly-report", Workflow: "ReportWorkflow", TaskQueue: "reports"},
+				Spec: &client.ScheduleSpec{
+					CronExpressions: []string{"0 3 * * *"},
+					Intervals:       []client.ScheduleIntervalSpec{{Every: time.Hour}},
+				},
+				State: &client.ScheduleState{Paused: true, Note: "paused during incident"},
+			},
+			Info: client.ScheduleInfo{
+				NumActions:      7,
+				NextActionTimes: []time.Time{next},
+				RecentActions: []client.ScheduleActionResult{{
+					ScheduleTime:        ran,
+					ActualTime:          ran,
+					StartWorkflowResult: &client.ScheduleWorkflowExecution{WorkflowID: "nightly-report-1", FirstExecutionRunID: "run-1"},
+				}},
+			},
+		}}
+
+		resp, err := describeScheduleHandler(mockClient)(DescribeScheduleParams{ScheduleID: "nightly"})
+		require.NoError(t, err)
+		require.Equal(t, "nightly", mockClient.lastScheduleID)
+
+		var result describeScheduleResult
+		require.NoError(t, json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &result))
+		require.True(t, result.Paused)
+		require.Equal(t, "paused during incident", result.Note)
+		require.Equal(t, []string{"0 3 * * *"}, result.Spec.CronExpressions)
+		require.Equal(t, []scheduleIntervalResult{{Every: "1h0m0s"}}, result.Spec.Intervals)
+		require.Equal(t, &scheduleActionResult{WorkflowType: "ReportWorkflow", WorkflowID: "nightly-report", TaskQueue: "reports"}, result.Action)
+		require.Equal(t, []time.Time{next}, result.NextActionTimes)
+		require.Equal(t, []scheduleRecentAction{{ScheduleTime: ran, ActualTime: ran, WorkflowID: "nightly-report-1", RunID: "run-1"}}, result.RecentActions)
+		require.Equal(t, 7, result.NumActions)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockClient := &mockTemporalClient{scheduleErr: serviceerror.NewNotFound("schedule not found")}
+		resp, err := asResponse(describeScheduleHandler(mockClient)(DescribeScheduleParams{ScheduleID: "missing"}))
+		require.NoError(t, err)
+		require.Equal(t, "Error: Schedule missing not found", resp.Content[0].TextContent.Text)
+	})
+}
+
+func TestPauseSchedule(t *testing.T) {
+	t.Run("pause", func(t *testing.T) {
+		mockClient := &mockTemporalClient{}
+		resp, err := pauseScheduleHandler(mockClient, true)(ScheduleNoteParams{ScheduleID: "nightly", Note: "incident 42"})
+		require.NoError(t, err)
+		require.Equal(t, "Schedule nightly paused", resp.Content[0].TextContent.Text)
+		require.Equal(t, "nightly", mockClient.lastScheduleID)
+		require.Equal(t, "pause", mockClient.lastScheduleOp)
+		require.Equal(t, "incident 42", mockClient.lastScheduleNote)
+	})
+
+	t.Run("unpause", func(t *testing.T) {
+		mockClient := &mockTemporalClient{}
+		resp, err := pauseScheduleHandler(mockClient, false)(ScheduleNoteParams{ScheduleID: "nightly", Note: "resolved"})
+		require.NoError(t, err)
+		require.Equal(t, "Schedule nightly unpaused", resp.Content[0].TextContent.Text)
+		require.Equal(t, "unpause", mockClient.lastScheduleOp)
+		require.Equal(t, "resolved", mockClient.lastScheduleNote)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockClient := &mockTemporalClient{scheduleErr: serviceerror.NewNotFound("schedule not found")}
+		resp, err := asResponse(pauseScheduleHandler(mockClient, true)(ScheduleNoteParams{ScheduleID: "missing"}))
+		require.NoError(t, err)
+		require.Equal(t, "Error: Schedule missing not found", resp.Content[0].TextContent.Text)
+	})
+}
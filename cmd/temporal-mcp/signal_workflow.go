@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/google/uuid"
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+)
+
+// SignalWorkflowParams are the arguments of the SignalWorkflow tool
+type SignalWorkflowParams struct {
+	NamespaceParam
+
+	WorkflowID string `json:"workflowId"`
+	RunID      string `json:"runId"`
+	SignalName string `json:"signalName"`
+	SignalArg  any    `json:"signalArg,omitempty"`
+
+	// Params render the signal's signalArgTemplates entry into its argument, instead of passing signalArg
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// registerSignalWorkflowTool registers a tool that sends a signal to a running workflow
+func registerSignalWorkflowTool(server *mcp.Server, tempClient client.Client, clients namespaceClients, cfg *config.Config) error {
+	desc := "Sends a signal (signalName, with an optional JSON signalArg) to a running workflow, e.g. to approve a step or " +
+		"push an event into a long-running workflow. The workflow must have a handler for the signal; signals to closed " +
+		"workflows fail. runId is optional - if omitted, this tool signals the latest run of the given workflowId"
+	if len(cfg.SignalArgTemplates) > 0 {
+		// Catch bad config at startup rather than on the first signal
+		if _, err := parseSignalArgTemplates(cfg); err != nil {
+			return err
+		}
+		names := make([]string, 0, len(cfg.SignalArgTemplates))
+		for name := range cfg.SignalArgTemplates {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		desc += ". For these signals, pass params (a map of named string inputs) instead of signalArg and the argument " +
+			"is built from them: " + strings.Join(names, ", ")
+	}
+
+	return registerTool(server, cfg, "SignalWorkflow", desc+namespaceDescription(cfg), namespacedHandler(tempClient, clients, cfg, signalWorkflowHandler))
+}
+
+func signalWorkflowHandler(tempClient client.Client, cfg *config.Config) func(args SignalWorkflowParams) (*mcp.ToolResponse, error) {
+	// registerSignalWorkflowTool rejects templates that don't parse, so the error is only kept for handlers built
+	// without it
+	templates, templatesErr := parseSignalArgTemplates(cfg)
+
+	return func(args SignalWorkflowParams) (*mcp.ToolResponse, error) {
+		// Check if Temporal client is available
+		if tempClient == nil {
+			log.Printf("Error: Temporal client is not available for signaling workflows")
+			return degradedResponse("Error: Temporal client is not available for signaling workflows")
+		}
+
+		if args.SignalName == "" {
+			return errorResponse("Error: signalName is required")
+		}
+
+		signalArg := args.SignalArg
+		if args.Params != nil {
+			if templatesErr != nil {
+				return errorResponse(fmt.Sprintf("Error: invalid signalArgTemplates: %v", templatesErr))
+			}
+			tmpl, ok := templates[args.SignalName]
+			if !ok {
+				return errorResponse(fmt.Sprintf("Error: signal %s has no signalArgTemplates entry - pass signalArg "+
+					"instead of params", args.SignalName))
+			}
+			if args.SignalArg != nil {
+				return errorResponse("Error: pass either signalArg or params, not both")
+			}
+			rendered, err := renderSignalArg(tmpl, args.Params)
+			if err != nil {
+				return errorResponse(fmt.Sprintf("Error: Failed to build the argument of signal %s from params: %v",
+					args.SignalName, err))
+			}
+			signalArg = rendered
+		}
+
+		err := sendSignal(context.Background(), tempClient, cfg, args.WorkflowID, args.RunID, args.SignalName, signalArg)
+		if err != nil {
+			msg := fmt.Sprintf("Error: Failed to send signal %s to workflow %s: %v", args.SignalName, args.WorkflowID, err)
+			var notFound *serviceerror.NotFound
+			if errors.As(err, &notFound) {
+				msg = fmt.Sprintf("Error: Workflow %s not found or already closed", args.WorkflowID)
+			}
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		msg := fmt.Sprintf("Sent signal %s to workflow %s (%s)", args.SignalName, args.WorkflowID, runLabel(args.RunID))
+		log.Print(msg)
+		return mcp.NewToolResponse(mcp.NewTextContent(msg)), nil
+	}
+}
+
+// parseSignalArgTemplates parses cfg's signalArgTemplates, keyed by signal name. Params are referenced like in a
+// workflow ID recipe ({{ .order_id }}); {{ json .order_id }} renders a param as a JSON string, quoted and escaped.
+// A template that references a param the call didn't pass fails instead of rendering "<no value>".
+func parseSignalArgTemplates(cfg *config.Config) (map[string]*template.Template, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	templates := make(map[string]*template.Template, len(cfg.SignalArgTemplates))
+	for name, text := range cfg.SignalArgTemplates {
+		tmpl, err := template.New(name).Option("missingkey=error").Funcs(template.FuncMap{
+			"json": func(value any) (string, error) {
+				encoded, err := json.Marshal(value)
+				return string(encoded), err
+			},
+		}).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("signalArgTemplates entry for signal %s: %w", name, err)
+		}
+		templates[name] = tmpl
+	}
+	return templates, nil
+}
+
+// renderSignalArg renders tmpl with params and decodes the result, which must be JSON, so the workflow receives the
+// structured value rather than its text
+func renderSignalArg(tmpl *template.Template, params map[string]string) (any, error) {
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, params); err != nil {
+		return nil, err
+	}
+	var arg any
+	if err := json.Unmarshal(rendered.Bytes(), &arg); err != nil {
+		return nil, fmt.Errorf("rendered %q, which is not valid JSON: %w", rendered.String(), err)
+	}
+	return arg, nil
+}
+
+// signalIdentity is recorded as the sender on signals this server sends without an argument
+const signalIdentity = "temporal-mcp"
+
+// sendSignal signals the workflow with arg. The SDK's SignalWorkflow always encodes its arg, so a nil arg would reach
+// the workflow as an explicit null; without an arg the signal is sent through the workflow service with no input
+// instead, like a signal sent with no arguments from the Temporal CLI.
+func sendSignal(ctx context.Context, tempClient client.Client, cfg *config.Config, workflowID, runID, signalName string, arg any) error {
+	if arg != nil {
+		return tempClient.SignalWorkflow(ctx, workflowID, runID, signalName, arg)
+	}
+	_, err := tempClient.WorkflowService().SignalWorkflowExecution(ctx, &workflowservice.SignalWorkflowExecutionRequest{
+		Namespace:         temporalNamespace(cfg),
+		WorkflowExecution: &common.WorkflowExecution{WorkflowId: workflowID, RunId: runID},
+		SignalName:        signalName,
+		Identity:          signalIdentity,
+		RequestId:         uuid.NewString(),
+	})
+	return err
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"testing"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	mcphttp "github.com/metoro-io/mcp-golang/transport/http"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/serviceerror"
+)
+
+func TestSignalWorkflow(t *testing.T) {
+	mockClient := &mockTemporalClient{}
+	args := SignalWorkflowParams{
+		WorkflowID: "order_1",
+		RunID:      "run-1",
+		SignalName: "approve",
+		SignalArg:  map[string]any{"approver": "ops"},
+	}
+
+	resp, err := signalWorkflowHandler(mockClient, nil)(args)
+	require.NoError(t, err)
+	require.Equal(t, "Sent signal approve to workflow order_1 (run run-1)", resp.Content[0].TextContent.Text)
+	require.Equal(t, "approve", mockClient.lastSignalName)
+	require.Equal(t, "run-1", mockClient.lastSignalRunID)
+	require.Equal(t, map[string]any{"approver": "ops"}, mockClient.lastSignalArg)
+	require.Nil(t, mockClient.lastSignalRequest)
+
+	t.Run("without signalArg", func(t *testing.T) {
+		mockClient := &mockTemporalClient{}
+		cfg := &config.Config{Temporal: config.TemporalConfig{Namespace: "orders"}}
+		_, err := signalWorkflowHandler(mockClient, cfg)(SignalWorkflowParams{WorkflowID: "order_1", SignalName: "approve"})
+		require.NoError(t, err)
+		require.NotNil(t, mockClient.lastSignalRequest, "the signal must not go through SignalWorkflow's encoded arg")
+		require.Nil(t, mockClient.lastSignalRequest.GetInput(), "the workflow must receive no arguments, not a null")
+		require.Equal(t, "orders", mockClient.lastSignalRequest.GetNamespace())
+		require.Equal(t, "order_1", mockClient.lastSignalRequest.GetWorkflowExecution().GetWorkflowId())
+		require.Equal(t, "approve", mockClient.lastSignalName)
+	})
+
+	t.Run("signalName is required", func(t *testing.T) {
+		resp, err := asResponse(signalWorkflowHandler(mockClient, nil)(SignalWorkflowParams{WorkflowID: "order_1"}))
+		require.NoError(t, err)
+		require.Equal(t, "Error: signalName is required", resp.Content[0].TextContent.Text)
+	})
+
+	t.Run("closed workflow", func(t *testing.T) {
+		mockClient := &mockTemporalClient{signalErr: serviceerror.NewNotFound("workflow execution already completed")}
+		resp, err := asResponse(signalWorkflowHandler(mockClient, nil)(args))
+		require.NoError(t, err)
+		require.Equal(t, "Error: Workflow order_1 not found or already closed", resp.Content[0].TextContent.Text)
+	})
+
+	t.Run("degraded mode", func(t *testing.T) {
+		resp, err := asResponse(signalWorkflowHandler(nil, nil)(args))
+		require.NoError(t, err)
+		require.Equal(t, "Error: Temporal client is not available for signaling workflows", resp.Content[0].TextContent.Text)
+	})
+
+	t.Run("templated signalArg", func(t *testing.T) {
+		mockClient := &mockTemporalClient{}
+		cfg := &config.Config{SignalArgTemplates: map[string]string{
+			"approve": `{"approver": {{ json .approver }}, "note": {{ json .note }}, "final": true}`,
+		}}
+		args := SignalWorkflowParams{
+			WorkflowID: "order_1",
+			SignalName: "approve",
+			Params:     map[string]string{"approver": "ops", "note": `"rush" order`},
+		}
+
+		_, err := signalWorkflowHandler(mockClient, cfg)(args)
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"approver": "ops", "note": `"rush" order`, "final": true}, mockClient.lastSignalArg)
+
+		resp, err := asResponse(signalWorkflowHandler(mockClient, cfg)(SignalWorkflowParams{
+			WorkflowID: "order_1", SignalName: "approve", Params: map[string]string{"approver": "ops"},
+		}))
+		require.NoError(t, err)
+		require.Contains(t, resp.Content[0].TextContent.Text, "Error: Failed to build the argument of signal approve")
+		require.Contains(t, resp.Content[0].TextContent.Text, "note")
+
+		resp, err = asResponse(signalWorkflowHandler(mockClient, cfg)(SignalWorkflowParams{
+			WorkflowID: "order_1", SignalName: "reject", Params: map[string]string{"approver": "ops"},
+		}))
+		require.NoError(t, err)
+		require.Equal(t, "Error: signal reject has no signalArgTemplates entry - pass signalArg instead of params",
+			resp.Content[0].TextContent.Text)
+	})
+
+	t.Run("invalid template rejected at registration", func(t *testing.T) {
+		cfg := &config.Config{SignalArgTemplates: map[string]string{"approve": `{"approver": {{ json .approver }`}}
+		server := mcp.NewServer(mcphttp.NewHTTPTransport("/mcp"))
+		err := registerSignalWorkflowTool(server, &mockTemporalClient{}, nil, cfg)
+		require.ErrorContains(t, err, "signalArgTemplates entry for signal approve")
+	})
+}
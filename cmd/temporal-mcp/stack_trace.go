@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"go.temporal.io/sdk/client"
+)
+
+// stackTraceQueryType is the built-in query every Go/Java/etc. worker answers with its workflow goroutine stacks
+const stackTraceQueryType = "__stack_trace"
+
+// GetWorkflowStackTraceParams are the arguments of the GetWorkflowStackTrace tool
+type GetWorkflowStackTraceParams struct {
+	NamespaceParam
+
+	WorkflowID string `json:"workflowId"`
+	RunID      string `json:"runId"`
+}
+
+// registerGetWorkflowStackTraceTool registers a tool that returns a running workflow's current stack trace
+func registerGetWorkflowStackTraceTool(server *mcp.Server, tempClient client.Client, clients namespaceClients, cfg *config.Config) error {
+	desc := "Gets the current stack trace of a running workflow via the built-in __stack_trace query - useful for diagnosing stuck or " +
+		"deadlocked workflows. runId is optional - if omitted, this tool queries the " + runSelector(cfg) + " run of the given workflowId"
+
+	return registerTool(server, cfg, "GetWorkflowStackTrace", desc+namespaceDescription(cfg), namespacedHandler(tempClient, clients, cfg, getWorkflowStackTraceHandler))
+}
+
+func getWorkflowStackTraceHandler(tempClient client.Client, cfg *config.Config) func(args GetWorkflowStackTraceParams) (*mcp.ToolResponse, error) {
+	return func(args GetWorkflowStackTraceParams) (*mcp.ToolResponse, error) {
+		// Check if Temporal client is available
+		if tempClient == nil {
+			log.Printf("Error: Temporal client is not available for getting workflow stack traces")
+			return degradedResponse("Error: Temporal client is not available for getting workflow stack traces")
+		}
+
+		runID, err := resolveRun(context.Background(), tempClient, cfg, args.WorkflowID, args.RunID)
+		if err != nil {
+			msg := fmt.Sprintf("Error: %v", err)
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		value, err := tempClient.QueryWorkflow(context.Background(), args.WorkflowID, runID, stackTraceQueryType)
+		if err != nil {
+			// Closed workflows, workflows without a running worker, and SDKs that don't implement the query all end up here
+			msg := fmt.Sprintf("Error: Could not get stack trace for workflow %s: %v. The workflow may have already completed, "+
+				"or no worker may be polling its task queue.", args.WorkflowID, err)
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		var stackTrace string
+		if err := value.Get(&stackTrace); err != nil {
+			msg := fmt.Sprintf("Error: Could not decode stack trace for workflow %s: %v", args.WorkflowID, err)
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		return mcp.NewToolResponse(mcp.NewTextContent(stackTrace)), nil
+	}
+}
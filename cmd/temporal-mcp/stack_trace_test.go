@@ -0,0 +1,29 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetWorkflowStackTrace(t *testing.T) {
+	stack := "coroutine root [blocked on chan-1.Receive]:\nmain.MyWorkflow(...)\n"
+
+	t.Run("returns the stack", func(t *testing.T) {
+		mockClient := &mockTemporalClient{queryResult: stack}
+		resp, err := getWorkflowStackTraceHandler(mockClient, nil)(GetWorkflowStackTraceParams{WorkflowID: "wf-1", RunID: "run-1"})
+		require.NoError(t, err)
+		require.Equal(t, stack, resp.Content[0].TextContent.Text)
+		require.Equal(t, stackTraceQueryType, mockClient.lastQueryType)
+		require.Equal(t, "run-1", mockClient.lastQueryRunID)
+	})
+
+	t.Run("query not supported", func(t *testing.T) {
+		mockClient := &mockTemporalClient{queryErr: errors.New("unknown queryType __stack_trace")}
+		resp, err := asResponse(getWorkflowStackTraceHandler(mockClient, nil)(GetWorkflowStackTraceParams{WorkflowID: "wf-1"}))
+		require.NoError(t, err)
+		require.Contains(t, resp.Content[0].TextContent.Text, "Could not get stack trace for workflow wf-1")
+		require.Contains(t, resp.Content[0].TextContent.Text, "unknown queryType")
+	})
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+)
+
+// CancelWorkflowParams are the arguments of the CancelWorkflow tool
+type CancelWorkflowParams struct {
+	NamespaceParam
+
+	WorkflowID string `json:"workflowId"`
+	RunID      string `json:"runId"`
+}
+
+// TerminateWorkflowParams are the arguments of the TerminateWorkflow tool
+type TerminateWorkflowParams struct {
+	NamespaceParam
+
+	WorkflowID string `json:"workflowId"`
+	RunID      string `json:"runId"`
+	Reason     string `json:"reason"`
+	Details    []any  `json:"details,omitempty"`
+}
+
+// registerCancelWorkflowTool registers a tool that requests cancellation of a workflow
+func registerCancelWorkflowTool(server *mcp.Server, tempClient client.Client, clients namespaceClients, cfg *config.Config) error {
+	desc := "Requests graceful cancellation of a running workflow. The workflow is notified and can clean up (e.g. run " +
+		"compensations) before it closes as canceled, so it may keep running for a while; use GetWorkflowHistory or " +
+		"WaitForStatus to see when it has stopped. runId is optional - if omitted, this tool cancels the " +
+		"latest run of the given workflowId"
+
+	return registerTool(server, cfg, "CancelWorkflow", desc+namespaceDescription(cfg), namespacedHandler(tempClient, clients, cfg,
+		func(tempClient client.Client, _ *config.Config) func(CancelWorkflowParams) (*mcp.ToolResponse, error) {
+			return cancelWorkflowHandler(tempClient)
+		}))
+}
+
+// registerTerminateWorkflowTool registers a tool that forcefully terminates a workflow
+func registerTerminateWorkflowTool(server *mcp.Server, tempClient client.Client, clients namespaceClients, cfg *config.Config) error {
+	desc := "Forcefully terminates a running workflow: it stops immediately, without running any cleanup code. Prefer " +
+		"CancelWorkflow unless the workflow is stuck or must stop right away. reason is required and is recorded in the " +
+		"workflow's history, along with the optional details. runId is optional - if omitted, this tool terminates the " +
+		"latest run of the given workflowId"
+
+	return registerTool(server, cfg, "TerminateWorkflow", desc+namespaceDescription(cfg), namespacedHandler(tempClient, clients, cfg,
+		func(tempClient client.Client, _ *config.Config) func(TerminateWorkflowParams) (*mcp.ToolResponse, error) {
+			return terminateWorkflowHandler(tempClient)
+		}))
+}
+
+func cancelWorkflowHandler(tempClient client.Client) func(args CancelWorkflowParams) (*mcp.ToolResponse, error) {
+	return func(args CancelWorkflowParams) (*mcp.ToolResponse, error) {
+		// Check if Temporal client is available
+		if tempClient == nil {
+			log.Printf("Error: Temporal client is not available for canceling workflows")
+			return degradedResponse("Error: Temporal client is not available for canceling workflows")
+		}
+
+		if err := tempClient.CancelWorkflow(context.Background(), args.WorkflowID, args.RunID); err != nil {
+			msg := stopWorkflowError("cancel", args.WorkflowID, err)
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		msg := fmt.Sprintf("Requested cancellation of workflow %s (%s)", args.WorkflowID, runLabel(args.RunID))
+		log.Print(msg)
+		return mcp.NewToolResponse(mcp.NewTextContent(msg)), nil
+	}
+}
+
+func terminateWorkflowHandler(tempClient client.Client) func(args TerminateWorkflowParams) (*mcp.ToolResponse, error) {
+	return func(args TerminateWorkflowParams) (*mcp.ToolResponse, error) {
+		// Check if Temporal client is available
+		if tempClient == nil {
+			log.Printf("Error: Temporal client is not available for terminating workflows")
+			return degradedResponse("Error: Temporal client is not available for terminating workflows")
+		}
+
+		if args.Reason == "" {
+			return errorResponse("Error: reason is required to terminate a workflow")
+		}
+
+		if err := tempClient.TerminateWorkflow(context.Background(), args.WorkflowID, args.RunID, args.Reason, args.Details...); err != nil {
+			msg := stopWorkflowError("terminate", args.WorkflowID, err)
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		msg := fmt.Sprintf("Terminated workflow %s (%s): %s", args.WorkflowID, runLabel(args.RunID), args.Reason)
+		log.Print(msg)
+		return mcp.NewToolResponse(mcp.NewTextContent(msg)), nil
+	}
+}
+
+// stopWorkflowError describes a failed cancel or terminate call
+func stopWorkflowError(action string, workflowID string, err error) string {
+	var notFound *serviceerror.NotFound
+	if errors.As(err, &notFound) {
+		return fmt.Sprintf("Error: Workflow %s not found or already closed", workflowID)
+	}
+	return fmt.Sprintf("Error: Failed to %s workflow %s: %v", action, workflowID, err)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/serviceerror"
+)
+
+func TestCancelWorkflow(t *testing.T) {
+	mockClient := &mockTemporalClient{}
+	resp, err := cancelWorkflowHandler(mockClient)(CancelWorkflowParams{WorkflowID: "order_1"})
+	require.NoError(t, err)
+	require.Equal(t, "Requested cancellation of workflow order_1 (latest run)", resp.Content[0].TextContent.Text)
+	require.Empty(t, mockClient.lastCancelRunID)
+
+	resp, err = cancelWorkflowHandler(mockClient)(CancelWorkflowParams{WorkflowID: "order_1", RunID: "run-1"})
+	require.NoError(t, err)
+	require.Equal(t, "Requested cancellation of workflow order_1 (run run-1)", resp.Content[0].TextContent.Text)
+	require.Equal(t, "run-1", mockClient.lastCancelRunID)
+
+	mockClient = &mockTemporalClient{stopErr: serviceerror.NewNotFound("workflow execution already completed")}
+	resp, err = asResponse(cancelWorkflowHandler(mockClient)(CancelWorkflowParams{WorkflowID: "order_1"}))
+	require.NoError(t, err)
+	require.Equal(t, "Error: Workflow order_1 not found or already closed", resp.Content[0].TextContent.Text)
+
+	resp, err = asResponse(cancelWorkflowHandler(nil)(CancelWorkflowParams{WorkflowID: "order_1"}))
+	require.NoError(t, err)
+	require.Equal(t, "Error: Temporal client is not available for canceling workflows", resp.Content[0].TextContent.Text)
+}
+
+func TestTerminateWorkflow(t *testing.T) {
+	mockClient := &mockTemporalClient{}
+	args := TerminateWorkflowParams{WorkflowID: "order_1", RunID: "run-1", Reason: "stuck on a bad deploy", Details: []any{"INC-7"}}
+	resp, err := terminateWorkflowHandler(mockClient)(args)
+	require.NoError(t, err)
+	require.Equal(t, "Terminated workflow order_1 (run run-1): stuck on a bad deploy", resp.Content[0].TextContent.Text)
+	require.Equal(t, "run-1", mockClient.lastTerminateRun)
+	require.Equal(t, "stuck on a bad deploy", mockClient.lastTerminateWhy)
+	require.Equal(t, []any{"INC-7"}, mockClient.lastTerminateArgs)
+
+	t.Run("reason is required", func(t *testing.T) {
+		resp, err := asResponse(terminateWorkflowHandler(mockClient)(TerminateWorkflowParams{WorkflowID: "order_1"}))
+		require.NoError(t, err)
+		require.Equal(t, "Error: reason is required to terminate a workflow", resp.Content[0].TextContent.Text)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		mockClient := &mockTemporalClient{stopErr: errors.New("permission denied")}
+		resp, err := asResponse(terminateWorkflowHandler(mockClient)(args))
+		require.NoError(t, err)
+		require.Equal(t, "Error: Failed to terminate workflow order_1: permission denied", resp.Content[0].TextContent.Text)
+	})
+
+	t.Run("degraded mode", func(t *testing.T) {
+		resp, err := asResponse(terminateWorkflowHandler(nil)(args))
+		require.NoError(t, err)
+		require.Equal(t, "Error: Temporal client is not available for terminating workflows", resp.Content[0].TextContent.Text)
+	})
+}
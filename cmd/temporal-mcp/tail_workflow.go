@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"github.com/mocksi/temporal-mcp/internal/sanitize_history_event"
+	temporal_enums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/client"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// defaultTailDuration is how long TailWorkflow follows a workflow when the caller doesn't specify a timeout
+	defaultTailDuration = 30 * time.Second
+	// maxTailDuration bounds how long a single TailWorkflow call may hold the tool call open
+	maxTailDuration = 5 * time.Minute
+)
+
+// TailWorkflowParams are the arguments of the TailWorkflow tool
+type TailWorkflowParams struct {
+	NamespaceParam
+
+	WorkflowID     string `json:"workflowId"`
+	RunID          string `json:"runId"`
+	TimeoutSeconds int    `json:"timeoutSeconds"`
+	AfterEventID   int64  `json:"afterEventId,omitempty"` // Only events after this one are returned
+}
+
+// tailWorkflowResult is the JSON document returned by the TailWorkflow tool
+type tailWorkflowResult struct {
+	WorkflowClosed bool              `json:"workflowClosed"`
+	TimedOut       bool              `json:"timedOut"`
+	LastEventID    int64             `json:"lastEventId"` // Pass as afterEventId to continue where this call stopped
+	Events         []json.RawMessage `json:"events"`
+}
+
+// registerTailWorkflowTool registers a tool that follows a workflow's history as new events arrive
+func registerTailWorkflowTool(server *mcp.Server, tempClient client.Client, clients namespaceClients, cfg *config.Config) error {
+	desc := fmt.Sprintf("Follows the execution history of a workflow, collecting new events as they arrive until the workflow closes "+
+		"or timeoutSeconds elapses (default %d, max %d). runId is optional - if omitted, this tool follows the %s run of the given workflowId. "+
+		"afterEventId is optional - if set, only events after it are returned; pass the lastEventId of the previous call to keep "+
+		"following a workflow without getting its earlier events again",
+		int(defaultTailDuration.Seconds()), int(maxTailDuration.Seconds()), runSelector(cfg))
+
+	return registerTool(server, cfg, "TailWorkflow", desc+namespaceDescription(cfg), namespacedHandler(tempClient, clients, cfg, tailWorkflowHandler))
+}
+
+// tailWorkflowHandler long-polls the workflow history. mcp-golang doesn't let tool handlers emit notifications, so the
+// events are collected and returned together once the workflow closes or the time budget is spent.
+func tailWorkflowHandler(tempClient client.Client, cfg *config.Config) func(args TailWorkflowParams) (*mcp.ToolResponse, error) {
+	return func(args TailWorkflowParams) (*mcp.ToolResponse, error) {
+		// Check if Temporal client is available
+		if tempClient == nil {
+			log.Printf("Error: Temporal client is not available for tailing workflows")
+			return degradedResponse("Error: Temporal client is not available for tailing workflows")
+		}
+
+		duration := defaultTailDuration
+		if args.TimeoutSeconds > 0 {
+			duration = time.Duration(args.TimeoutSeconds) * time.Second
+		}
+		if duration > maxTailDuration {
+			duration = maxTailDuration
+		}
+
+		runID, err := resolveRun(context.Background(), tempClient, cfg, args.WorkflowID, args.RunID)
+		if err != nil {
+			msg := fmt.Sprintf("Error: %v", err)
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), duration)
+		defer cancel()
+
+		result := tailWorkflowResult{LastEventID: args.AfterEventID, Events: make([]json.RawMessage, 0)}
+		iterator := tempClient.GetWorkflowHistory(ctx, args.WorkflowID, runID, true, temporal_enums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+		for iterator.HasNext() {
+			event, err := iterator.Next()
+			if err != nil {
+				if ctx.Err() != nil {
+					result.TimedOut = true
+					break
+				}
+				msg := fmt.Sprintf("Error: Failed to get %dth history event: %v", len(result.Events), err)
+				log.Print(msg)
+				return errorResponse(msg)
+			}
+
+			// The history is always read from the start; events the caller has already seen are skipped, though a
+			// close event still ends the tail
+			if event.GetEventId() > args.AfterEventID {
+				result.LastEventID = event.GetEventId()
+				sanitize_history_event.SanitizeHistoryEvent(event)
+				bytes, err := protojson.Marshal(event)
+				if err != nil {
+					return nil, err
+				}
+				result.Events = append(result.Events, bytes)
+			}
+
+			if isWorkflowCloseEvent(event.GetEventType()) {
+				result.WorkflowClosed = true
+				break
+			}
+		}
+
+		bytes, err := json.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResponse(mcp.NewTextContent(string(bytes))), nil
+	}
+}
+
+// isWorkflowCloseEvent reports whether the event type ends a workflow run
+func isWorkflowCloseEvent(eventType temporal_enums.EventType) bool {
+	switch eventType {
+	case temporal_enums.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED,
+		temporal_enums.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED,
+		temporal_enums.EVENT_TYPE_WORKFLOW_EXECUTION_CANCELED,
+		temporal_enums.EVENT_TYPE_WORKFLOW_EXECUTION_TERMINATED,
+		temporal_enums.EVENT_TYPE_WORKFLOW_EXECUTION_TIMED_OUT,
+		temporal_enums.EVENT_TYPE_WORKFLOW_EXECUTION_CONTINUED_AS_NEW:
+		return true
+	}
+	return false
+}
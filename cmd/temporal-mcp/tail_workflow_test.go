@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	temporal_enums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/history/v1"
+)
+
+func TestTailWorkflowStopsAtCloseEvent(t *testing.T) {
+	mockClient := &mockTemporalClient{
+		historyEvents: []*history.HistoryEvent{
+			historyEvent(1, temporal_enums.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED),
+			historyEvent(2, temporal_enums.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED),
+			historyEvent(3, temporal_enums.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED),
+			// Never reached - tailing stops at the close event
+			historyEvent(4, temporal_enums.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED),
+		},
+	}
+
+	resp, err := tailWorkflowHandler(mockClient, nil)(TailWorkflowParams{WorkflowID: "wf-1"})
+	require.NoError(t, err)
+	require.True(t, mockClient.lastHistoryPolled, "tail should long-poll the history")
+
+	var result tailWorkflowResult
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &result))
+	require.True(t, result.WorkflowClosed)
+	require.False(t, result.TimedOut)
+	require.Len(t, result.Events, 3)
+}
+
+func TestTailWorkflowWithoutClient(t *testing.T) {
+	resp, err := asResponse(tailWorkflowHandler(nil, nil)(TailWorkflowParams{WorkflowID: "wf-1"}))
+	require.NoError(t, err)
+	require.Contains(t, resp.Content[0].TextContent.Text, "Temporal client is not available")
+}
+
+func TestTailWorkflowAfterEventID(t *testing.T) {
+	mockClient := &mockTemporalClient{
+		historyEvents: []*history.HistoryEvent{
+			historyEvent(1, temporal_enums.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED),
+			historyEvent(2, temporal_enums.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED),
+			historyEvent(3, temporal_enums.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED),
+		},
+	}
+	tail := func(afterEventID int64) tailWorkflowResult {
+		resp, err := tailWorkflowHandler(mockClient, nil)(TailWorkflowParams{WorkflowID: "wf-1", AfterEventID: afterEventID})
+		require.NoError(t, err)
+		var result tailWorkflowResult
+		require.NoError(t, json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &result))
+		return result
+	}
+
+	result := tail(1)
+	require.True(t, result.WorkflowClosed)
+	require.Equal(t, int64(3), result.LastEventID)
+	require.Len(t, result.Events, 2, "only the events after the cursor are returned")
+	require.Contains(t, string(result.Events[0]), `"eventId":"2"`)
+
+	// A caller that has seen the close event gets no events, but learns the workflow has closed
+	result = tail(3)
+	require.True(t, result.WorkflowClosed)
+	require.Equal(t, int64(3), result.LastEventID)
+	require.Empty(t, result.Events)
+}
+
+func TestTailWorkflowTimesOutWhenHistoryStopsGrowing(t *testing.T) {
+	mockClient := &mockTemporalClient{
+		historyEvents: []*history.HistoryEvent{
+			historyEvent(1, temporal_enums.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED),
+			historyEvent(2, temporal_enums.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED),
+		},
+		historyBlocks: true,
+	}
+
+	start := time.Now()
+	resp, err := tailWorkflowHandler(mockClient, nil)(TailWorkflowParams{WorkflowID: "wf-1", TimeoutSeconds: 1})
+	require.NoError(t, err)
+	require.Less(t, time.Since(start), 3*time.Second, "the tail must stop at its deadline")
+
+	var result tailWorkflowResult
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &result))
+	require.True(t, result.TimedOut)
+	require.False(t, result.WorkflowClosed)
+	require.Equal(t, int64(2), result.LastEventID)
+	require.Len(t, result.Events, 2, "events read before the deadline are kept")
+}
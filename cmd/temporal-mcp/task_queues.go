@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+	temporal_enums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/client"
+)
+
+// taskQueueCheckTimeout bounds each DescribeTaskQueue call made to look for pollers
+const taskQueueCheckTimeout = 5 * time.Second
+
+// workerPollInterval is how often waitForWorker re-checks an idle task queue for pollers
+var workerPollInterval = time.Second
+
+// routeTaskQueue picks the task queue the routing table maps the call's routing param value to. ok is false when there
+// is no table or no rule matches, in which case the workflow's own (or the default) task queue applies.
+func routeTaskQueue(routing *config.TaskQueueRoutingDef, params map[string]string) (string, bool) {
+	if routing == nil || routing.Param == "" {
+		return "", false
+	}
+	value, present := params[routing.Param]
+	if !present {
+		return "", false
+	}
+	taskQueue, ok := routing.Routes[value]
+	return taskQueue, ok && taskQueue != ""
+}
+
+// taskQueuePollerWarning returns a warning if no worker is polling taskQueue for workflow tasks, or "" if one is. A
+// failed check is logged and treated as "can't tell", so it never blocks a workflow from starting.
+func taskQueuePollerWarning(ctx context.Context, tempClient client.Client, taskQueue string) string {
+	resp, err := tempClient.DescribeTaskQueue(ctx, taskQueue, temporal_enums.TASK_QUEUE_TYPE_WORKFLOW)
+	if err != nil {
+		log.Printf("Could not check for workers polling task queue %s: %v", taskQueue, err)
+		return ""
+	}
+	if len(resp.GetPollers()) > 0 {
+		return ""
+	}
+	return fmt.Sprintf("Warning: no worker is polling task queue %s - workflows started on it will not make progress "+
+		"until a worker for it is running", taskQueue)
+}
+
+// waitForWorker checks taskQueue for workflow pollers until one appears or ctx is done, re-checking every
+// workerPollInterval. It returns false only if no poller appeared in time; a failed check is logged and treated as
+// "can't tell", like taskQueuePollerWarning does, so it never blocks a workflow from starting.
+func waitForWorker(ctx context.Context, tempClient client.Client, taskQueue string) bool {
+	ticker := time.NewTicker(workerPollInterval)
+	defer ticker.Stop()
+	for {
+		checkCtx, cancel := context.WithTimeout(ctx, taskQueueCheckTimeout)
+		resp, err := tempClient.DescribeTaskQueue(checkCtx, taskQueue, temporal_enums.TASK_QUEUE_TYPE_WORKFLOW)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return false
+			}
+			log.Printf("Could not check for workers polling task queue %s: %v", taskQueue, err)
+			return true
+		}
+		if len(resp.GetPollers()) > 0 {
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+}
+
+// namespacedTaskQueue is a task queue in a specific namespace; queues of the same name in different namespaces are
+// unrelated and have their own pollers
+type namespacedTaskQueue struct {
+	namespace string
+	taskQueue string
+}
+
+// warnIdleTaskQueues checks each distinct task queue used by the configured workflows and logs a warning for those
+// without pollers. Each queue is described through clientFor's client for the workflow's namespace, and each
+// DescribeTaskQueue call gets its own taskQueueCheckTimeout. The warnings are returned as well.
+func warnIdleTaskQueues(ctx context.Context, clientFor func(config.WorkflowDef) client.Client, cfg *config.Config) []string {
+	queues := map[namespacedTaskQueue]client.Client{}
+	for _, workflow := range cfg.Workflows {
+		tempClient := clientFor(workflow)
+		if tempClient == nil {
+			// Connection failures were already logged
+			continue
+		}
+		namespace := workflow.Namespace
+		if namespace == "" {
+			namespace = cfg.Temporal.Namespace
+		}
+		taskQueue := workflow.TaskQueue
+		if taskQueue == "" {
+			taskQueue = cfg.Temporal.DefaultTaskQueue
+		}
+		if taskQueue != "" {
+			queues[namespacedTaskQueue{namespace, taskQueue}] = tempClient
+		}
+		if workflow.TaskQueueRouting != nil {
+			for _, routed := range workflow.TaskQueueRouting.Routes {
+				if routed != "" {
+					queues[namespacedTaskQueue{namespace, routed}] = tempClient
+				}
+			}
+		}
+	}
+
+	sorted := make([]namespacedTaskQueue, 0, len(queues))
+	for queue := range queues {
+		sorted = append(sorted, queue)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].namespace != sorted[j].namespace {
+			return sorted[i].namespace < sorted[j].namespace
+		}
+		return sorted[i].taskQueue < sorted[j].taskQueue
+	})
+
+	var warnings []string
+	for _, queue := range sorted {
+		checkCtx, cancel := context.WithTimeout(ctx, taskQueueCheckTimeout)
+		warning := taskQueuePollerWarning(checkCtx, queues[queue], queue.taskQueue)
+		cancel()
+		if warning != "" {
+			if queue.namespace != cfg.Temporal.Namespace {
+				warning += fmt.Sprintf(" (namespace %s)", queue.namespace)
+			}
+			log.Print(warning)
+			warnings = append(warnings, warning)
+		}
+	}
+	return warnings
+}
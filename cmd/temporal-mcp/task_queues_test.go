@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/taskqueue/v1"
+	"go.temporal.io/sdk/client"
+)
+
+func TestWarnIdleTaskQueues(t *testing.T) {
+	mockClient := &mockTemporalClient{taskQueuePollers: map[string][]*taskqueue.PollerInfo{
+		"busy": {{Identity: "worker-1"}},
+	}}
+	cfg := &config.Config{
+		Temporal: config.TemporalConfig{DefaultTaskQueue: "default-queue"},
+		Workflows: map[string]config.WorkflowDef{
+			"A": {TaskQueue: "busy"},
+			"B": {TaskQueue: "idle"},
+			"C": {TaskQueue: "idle"},
+			"D": {},
+		},
+	}
+
+	clientFor := func(config.WorkflowDef) client.Client { return mockClient }
+	warnings := warnIdleTaskQueues(context.Background(), clientFor, cfg)
+	require.Len(t, warnings, 2)
+	require.Contains(t, warnings[0], "task queue default-queue")
+	require.Contains(t, warnings[1], "task queue idle")
+	require.ElementsMatch(t, []string{"busy", "default-queue", "idle"}, mockClient.describeTaskQueueCalls)
+}
+
+func TestWarnIdleTaskQueuesUsesNamespaceClients(t *testing.T) {
+	defaultClient := &mockTemporalClient{}
+	billingClient := &mockTemporalClient{taskQueuePollers: map[string][]*taskqueue.PollerInfo{
+		"shared": {{Identity: "billing-worker"}},
+	}}
+	cfg := &config.Config{
+		Temporal: config.TemporalConfig{Namespace: "default"},
+		Workflows: map[string]config.WorkflowDef{
+			"A": {TaskQueue: "shared"},
+			"B": {TaskQueue: "shared", Namespace: "billing"},
+			"C": {TaskQueue: "invoices", Namespace: "billing"},
+			"D": {TaskQueue: "orphaned", Namespace: "unreachable"},
+		},
+	}
+	clientFor := func(workflow config.WorkflowDef) client.Client {
+		switch workflow.Namespace {
+		case "":
+			return defaultClient
+		case "billing":
+			return billingClient
+		}
+		return nil
+	}
+
+	warnings := warnIdleTaskQueues(context.Background(), clientFor, cfg)
+	require.Equal(t, []string{"shared"}, defaultClient.describeTaskQueueCalls)
+	require.ElementsMatch(t, []string{"shared", "invoices"}, billingClient.describeTaskQueueCalls)
+	require.Len(t, warnings, 2)
+	require.Contains(t, warnings[0], "task queue invoices")
+	require.Contains(t, warnings[0], "(namespace billing)")
+	require.Contains(t, warnings[1], "task queue shared")
+	require.NotContains(t, warnings[1], "namespace")
+}
+
+func TestWorkflowToolWarnsAboutIdleTaskQueue(t *testing.T) {
+	mockClient := &mockTemporalClient{runResult: "done"}
+	cfg := &config.Config{CheckTaskQueuePollers: true}
+
+	resp, err := workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, cfg)(WorkflowParams{Params: map[string]string{"order_id": "1"}})
+	require.NoError(t, err)
+	require.Len(t, resp.Content, 2)
+	require.Equal(t, "done", resp.Content[0].TextContent.Text)
+	require.Contains(t, resp.Content[1].TextContent.Text, "no worker is polling task queue orders")
+
+	// Disabled by default
+	mockClient = &mockTemporalClient{runResult: "done"}
+	resp, err = workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, &config.Config{})(WorkflowParams{Params: map[string]string{"order_id": "1"}})
+	require.NoError(t, err)
+	require.Len(t, resp.Content, 1)
+	require.Empty(t, mockClient.describeTaskQueueCalls)
+}
+
+func TestWorkflowToolWaitsForWorker(t *testing.T) {
+	defer func(interval time.Duration) { workerPollInterval = interval }(workerPollInterval)
+	workerPollInterval = 10 * time.Millisecond
+	args := WorkflowParams{Params: map[string]string{"order_id": "1"}}
+	pollers := map[string][]*taskqueue.PollerInfo{"orders": {{Identity: "worker-1"}}}
+
+	t.Run("starts once a worker appears", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runResult: "done", taskQueuePollers: pollers, idleTaskQueueCalls: 3}
+		cfg := &config.Config{WorkerWaitTimeout: "5s"}
+
+		resp, err := workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, cfg)(args)
+		require.NoError(t, err)
+		require.Len(t, resp.Content, 1)
+		require.Equal(t, "done", resp.Content[0].TextContent.Text)
+		require.Len(t, mockClient.describeTaskQueueCalls, 4)
+		require.Equal(t, "orders", mockClient.lastStartOptions.TaskQueue)
+	})
+
+	t.Run("fails fast when no worker appears", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runResult: "done"}
+		cfg := &config.Config{WorkerWaitTimeout: "50ms"}
+
+		resp, err := asResponse(workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, cfg)(args))
+		require.NoError(t, err)
+		require.Contains(t, resp.Content[0].TextContent.Text, "Error: no worker available")
+		require.Contains(t, resp.Content[0].TextContent.Text, "task queue orders within 50ms")
+		require.Empty(t, mockClient.lastStartOptions.ID, "workflow must not be started")
+	})
+}
+
+func TestTaskQueueRouting(t *testing.T) {
+	workflow := testWorkflow()
+	workflow.Input.Fields = append(workflow.Input.Fields, map[string]string{"region": "Optional region"})
+	workflow.TaskQueueRouting = &config.TaskQueueRoutingDef{
+		Param:  "region",
+		Routes: map[string]string{"eu": "orders-eu", "us": "orders-us"},
+	}
+
+	tests := map[string]struct {
+		params        map[string]string
+		expectedQueue string
+	}{
+		"routed to eu":        {params: map[string]string{"order_id": "1", "region": "eu"}, expectedQueue: "orders-eu"},
+		"routed to us":        {params: map[string]string{"order_id": "2", "region": "us"}, expectedQueue: "orders-us"},
+		"unknown value":       {params: map[string]string{"order_id": "3", "region": "apac"}, expectedQueue: "orders"},
+		"routing param unset": {params: map[string]string{"order_id": "4"}, expectedQueue: "orders"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &mockTemporalClient{runResult: "ok"}
+			_, err := workflowToolHandler("OrderWorkflow", workflow, mockClient, nil)(WorkflowParams{Params: tc.params})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedQueue, mockClient.lastStartOptions.TaskQueue)
+		})
+	}
+}
@@ -0,0 +1,391 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/invopop/jsonschema"
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/mocksi/temporal-mcp/internal/config"
+)
+
+// registerTool registers a tool with the MCP server, wrapping its handler with the behavior shared by every tool
+func registerTool[T any](server *mcp.Server, cfg *config.Config, name string, description string, handler func(args T) (*mcp.ToolResponse, error)) error {
+	name = toolName(cfg, name)
+	if err := server.RegisterTool(name, description, wrapToolHandler(cfg, handler)); err != nil {
+		return err
+	}
+	recordTool(server, name, description, reflect.TypeFor[T]())
+	return nil
+}
+
+// wrapToolHandler adds the behavior shared by every tool to a handler: the degraded mode message, the response envelope
+// and the response size limit
+func wrapToolHandler[T any](cfg *config.Config, handler func(args T) (*mcp.ToolResponse, error)) func(args T) (*mcp.ToolResponse, error) {
+	wrapped := withDegradedModeMessage(cfg.DegradedModeMessage, handler)
+	if cfg.ResponseEnvelope {
+		// The envelope applies the limit itself, so that a cut response is still a valid envelope
+		return withEnvelope(cfg.MaxToolResponseBytes, wrapped)
+	}
+	return withResponseLimit(cfg.MaxToolResponseBytes, withToolErrors(wrapped))
+}
+
+// toolName is the name a tool is registered under: its name with the configured toolPrefix, so that several servers'
+// tools can be merged into one client without colliding
+func toolName(cfg *config.Config, name string) string {
+	if cfg == nil {
+		return name
+	}
+	return cfg.ToolPrefix + name
+}
+
+// toolDefinition is a registered tool as clients see it in tools/list
+type toolDefinition struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	InputSchema *jsonschema.Schema `json:"inputSchema"`
+}
+
+// toolSchemaReflector generates input schemas with the same settings mcp-golang uses for tools/list
+var toolSchemaReflector = jsonschema.Reflector{
+	Anonymous:                  true,
+	AllowAdditionalProperties:  true,
+	RequiredFromJSONSchemaTags: true,
+	DoNotReference:             true,
+	ExpandedStruct:             true,
+}
+
+// toolCatalogs records the tools registered on each server, in registration order. mcp-golang doesn't expose its
+// registered tools, so registerTool keeps its own record.
+var (
+	toolCatalogsMu sync.Mutex
+	toolCatalogs   = map[*mcp.Server][]toolDefinition{}
+)
+
+// recordTool adds a tool to the server's catalog, replacing an earlier registration with the same name
+func recordTool(server *mcp.Server, name string, description string, argsType reflect.Type) {
+	definition := toolDefinition{Name: name, Description: description, InputSchema: toolSchemaReflector.ReflectFromType(argsType)}
+
+	toolCatalogsMu.Lock()
+	defer toolCatalogsMu.Unlock()
+	for i, existing := range toolCatalogs[server] {
+		if existing.Name == name {
+			toolCatalogs[server][i] = definition
+			return
+		}
+	}
+	toolCatalogs[server] = append(toolCatalogs[server], definition)
+}
+
+// registeredTools returns a copy of the server's tool catalog
+func registeredTools(server *mcp.Server) []toolDefinition {
+	toolCatalogsMu.Lock()
+	defer toolCatalogsMu.Unlock()
+	return append([]toolDefinition(nil), toolCatalogs[server]...)
+}
+
+// GetToolSchemasParams are the arguments of the GetToolSchemas tool
+type GetToolSchemasParams struct{}
+
+// registerGetToolSchemasTool registers a tool that returns every registered tool's definition and input schema
+func registerGetToolSchemasTool(server *mcp.Server, cfg *config.Config) error {
+	desc := "Returns the definitions of all tools this server provides - name, description and JSON schema of the input - as " +
+		"a single JSON document. Useful for generating clients and for debugging"
+
+	return registerTool(server, cfg, "GetToolSchemas", desc, getToolSchemasHandler(server))
+}
+
+func getToolSchemasHandler(server *mcp.Server) func(args GetToolSchemasParams) (*mcp.ToolResponse, error) {
+	return func(args GetToolSchemasParams) (*mcp.ToolResponse, error) {
+		bytes, err := json.MarshalIndent(map[string]any{"tools": registeredTools(server)}, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResponse(mcp.NewTextContent(string(bytes))), nil
+	}
+}
+
+// toolEnvelope is the uniform JSON shape of tool responses when cfg.ResponseEnvelope is set
+type toolEnvelope struct {
+	OK        bool               `json:"ok"`
+	Data      json.RawMessage    `json:"data,omitempty"`
+	Error     *toolEnvelopeError `json:"error,omitempty"`
+	Warnings  []string           `json:"warnings,omitempty"`
+	Truncated bool               `json:"truncated,omitempty"` // The data (or error message) was cut to fit maxToolResponseBytes
+}
+
+type toolEnvelopeError struct {
+	Message string       `json:"message"`
+	Fields  []paramError `json:"fields,omitempty"` // Each invalid param, for workflow param errors
+}
+
+// withEnvelope wraps a handler's responses in a toolEnvelope of at most maxBytes (no limit when maxBytes <= 0). A
+// response's first text content is the data (embedded as JSON if it is JSON, otherwise as a string) and a toolError's
+// message and param errors are the error; any further text contents are warnings. Other handler errors become error
+// envelopes too.
+func withEnvelope[T any](maxBytes int, handler func(args T) (*mcp.ToolResponse, error)) func(args T) (*mcp.ToolResponse, error) {
+	return func(args T) (*mcp.ToolResponse, error) {
+		resp, err := handler(args)
+		var envelope toolEnvelope
+		var notes []*mcp.Content
+		var data string
+		var toolErr *toolError
+		switch {
+		case errors.As(err, &toolErr):
+			envelope.Error = &toolEnvelopeError{Message: toolErr.message, Fields: toolErr.fields}
+			notes = toolErr.notes
+		case err != nil:
+			envelope.Error = &toolEnvelopeError{Message: err.Error()}
+		case resp == nil:
+			envelope.OK = true
+		default:
+			envelope.OK = true
+			notes = resp.Content
+			for i, content := range resp.Content {
+				if content.TextContent != nil {
+					data = content.TextContent.Text
+					envelope.Data = envelopeData(data)
+					notes = resp.Content[i+1:]
+					break
+				}
+			}
+		}
+		for _, content := range notes {
+			if content.TextContent != nil {
+				envelope.Warnings = append(envelope.Warnings, content.TextContent.Text)
+			}
+		}
+
+		bytes, err := marshalEnvelope(envelope, data, maxBytes)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResponse(mcp.NewTextContent(string(bytes))), nil
+	}
+}
+
+// marshalEnvelope encodes an envelope whose data is the given text in at most maxBytes (no limit when maxBytes <= 0).
+// An envelope that doesn't fit has its data - or, for errors, its error message - cut and embedded as a string, so it
+// stays valid JSON, and is marked truncated.
+func marshalEnvelope(envelope toolEnvelope, data string, maxBytes int) ([]byte, error) {
+	bytes, err := json.Marshal(envelope)
+	if err != nil || maxBytes <= 0 || len(bytes) <= maxBytes {
+		return bytes, err
+	}
+
+	text, set := data, func(cut string) { envelope.Data, _ = json.Marshal(cut) }
+	if envelope.Error != nil {
+		text, set = envelope.Error.Message, func(cut string) { envelope.Error.Message = cut }
+	}
+	envelope.Truncated = true
+	truncatedWarning := fmt.Sprintf("Truncated: response was %d bytes, limit is %d bytes", len(bytes), maxBytes)
+	envelope.Warnings = append(envelope.Warnings, truncatedWarning)
+
+	// Escaping can make the cut text's encoding longer than the text itself, so cut again until the envelope fits
+	budget := len(text)
+	for {
+		cut := cutText(text, budget)
+		set(cut)
+		if bytes, err = json.Marshal(envelope); err != nil || len(bytes) <= maxBytes {
+			return bytes, err
+		}
+		if cut == "" {
+			break
+		}
+		budget = max(len(cut)-(len(bytes)-maxBytes), 0)
+	}
+	// Even without its text the envelope is too large, so its other warnings go too
+	envelope.Warnings = []string{truncatedWarning}
+	return json.Marshal(envelope)
+}
+
+// envelopeData embeds text in an envelope: as-is when it is JSON, as a JSON string otherwise
+func envelopeData(text string) json.RawMessage {
+	if json.Valid([]byte(text)) {
+		return json.RawMessage(text)
+	}
+	bytes, _ := json.Marshal(text)
+	return bytes
+}
+
+// toolError is a failed tool call. Handlers return it rather than a response, so that wrappers can tell failures from
+// results without reading their text; withToolErrors turns it back into the response clients get.
+type toolError struct {
+	message  string
+	notes    []*mcp.Content // Sent after the message, e.g. hints or a link to the workflow in the Temporal UI
+	fields   []paramError   // Each invalid param, for clients that itemize them (only the envelope includes them)
+	degraded bool           // The call needs the Temporal client the server couldn't create
+}
+
+func (e *toolError) Error() string {
+	return e.message
+}
+
+// errorResponse fails a tool call with the given message, followed by any notes
+func errorResponse(message string, notes ...*mcp.Content) (*mcp.ToolResponse, error) {
+	return nil, &toolError{message: message, notes: notes}
+}
+
+// paramErrorResponse fails a workflow tool call with the given message, itemizing the invalid params
+func paramErrorResponse(message string, fields []paramError) (*mcp.ToolResponse, error) {
+	return nil, &toolError{message: message, fields: fields}
+}
+
+// degradedResponse fails a tool call because the server runs without a Temporal client
+func degradedResponse(message string) (*mcp.ToolResponse, error) {
+	return nil, &toolError{message: message, degraded: true}
+}
+
+// asResponse returns the response to send for a tool call: a toolError becomes its message and notes, anything else is
+// returned as-is
+func asResponse(resp *mcp.ToolResponse, err error) (*mcp.ToolResponse, error) {
+	var toolErr *toolError
+	if errors.As(err, &toolErr) {
+		return mcp.NewToolResponse(append([]*mcp.Content{mcp.NewTextContent(toolErr.message)}, toolErr.notes...)...), nil
+	}
+	return resp, err
+}
+
+// withToolErrors sends a handler's toolErrors as responses. Other errors are left to mcp-golang, which reports them as
+// failed calls with just the error text.
+func withToolErrors[T any](handler func(args T) (*mcp.ToolResponse, error)) func(args T) (*mcp.ToolResponse, error) {
+	return func(args T) (*mcp.ToolResponse, error) {
+		return asResponse(handler(args))
+	}
+}
+
+// withDegradedModeMessage appends message to a handler's degraded mode errors, e.g. so they point users to a status
+// page or say when the service is expected back (no change when message is empty)
+func withDegradedModeMessage[T any](message string, handler func(args T) (*mcp.ToolResponse, error)) func(args T) (*mcp.ToolResponse, error) {
+	if message == "" {
+		return handler
+	}
+	return func(args T) (*mcp.ToolResponse, error) {
+		resp, err := handler(args)
+		var toolErr *toolError
+		if errors.As(err, &toolErr) && toolErr.degraded {
+			toolErr.message += " " + message
+		}
+		return resp, err
+	}
+}
+
+// withResponseLimit caps the size of a handler's responses at maxBytes (no cap when maxBytes <= 0)
+func withResponseLimit[T any](maxBytes int, handler func(args T) (*mcp.ToolResponse, error)) func(args T) (*mcp.ToolResponse, error) {
+	if maxBytes <= 0 {
+		return handler
+	}
+	return func(args T) (*mcp.ToolResponse, error) {
+		resp, err := handler(args)
+		if err != nil || resp == nil {
+			return resp, err
+		}
+		return truncateToolResponse(resp, maxBytes), nil
+	}
+}
+
+// truncateToolResponse shortens the text content of a response so that, together, it fits in maxBytes. The first text
+// past the budget is cut (on a rune boundary) and ends with a marker saying how much was dropped. The texts after it -
+// warnings, notes, the link to the Temporal UI - are short and often what the caller needs next, so room is kept for
+// as many of them as fit.
+func truncateToolResponse(resp *mcp.ToolResponse, maxBytes int) *mcp.ToolResponse {
+	total := 0
+	for _, content := range resp.Content {
+		if content.TextContent != nil {
+			total += len(content.TextContent.Text)
+		}
+	}
+	if total <= maxBytes {
+		return resp
+	}
+
+	marker := fmt.Sprintf("\n...[truncated: response was %d bytes, limit is %d bytes]", total, maxBytes)
+	budget := max(maxBytes-len(marker), 0)
+
+	truncated := make([]*mcp.Content, 0, len(resp.Content))
+	for i, content := range resp.Content {
+		if content.TextContent == nil {
+			truncated = append(truncated, content)
+			continue
+		}
+		text := content.TextContent.Text
+		if len(text) <= budget {
+			budget -= len(text)
+			truncated = append(truncated, content)
+			continue
+		}
+
+		var later []*mcp.Content
+		for _, next := range resp.Content[i+1:] {
+			if next.TextContent != nil {
+				if len(next.TextContent.Text) > budget {
+					continue
+				}
+				budget -= len(next.TextContent.Text)
+			}
+			later = append(later, next)
+		}
+		truncated = append(truncated, mcp.NewTextContent(cutText(text, budget)+marker))
+		truncated = append(truncated, later...)
+		break
+	}
+
+	return mcp.NewToolResponse(truncated...)
+}
+
+// exceedsResponseLimit reports whether texts, sent together, are larger than cfg's maxToolResponseBytes
+func exceedsResponseLimit(cfg *config.Config, texts ...string) bool {
+	if cfg == nil || cfg.MaxToolResponseBytes <= 0 {
+		return false
+	}
+	size := 0
+	for _, text := range texts {
+		size += len(text)
+	}
+	return size > cfg.MaxToolResponseBytes
+}
+
+// resultSummary describes the shape of a workflow result too large to return, in place of the result
+type resultSummary struct {
+	Type     string   `json:"type"`               // "object" or "array"
+	Bytes    int      `json:"bytes"`              // Size of the formatted result
+	Keys     []string `json:"keys,omitempty"`     // The object's keys
+	Length   int      `json:"length,omitempty"`   // The array's length
+	ItemKeys []string `json:"itemKeys,omitempty"` // The keys of the array's first item, when it is an object
+}
+
+// summarizeResult summarizes a decoded workflow result formatted as size bytes. ok is false for results that aren't
+// objects or arrays, which have no shape to describe and are truncated instead.
+func summarizeResult(result any, size int) (summary resultSummary, ok bool) {
+	switch value := result.(type) {
+	case map[string]any:
+		return resultSummary{Type: "object", Bytes: size, Keys: slices.Sorted(maps.Keys(value))}, true
+	case []any:
+		summary := resultSummary{Type: "array", Bytes: size, Length: len(value)}
+		if len(value) > 0 {
+			if item, isObject := value[0].(map[string]any); isObject {
+				summary.ItemKeys = slices.Sorted(maps.Keys(item))
+			}
+		}
+		return summary, true
+	}
+	return resultSummary{}, false
+}
+
+// cutText returns the longest prefix of text of at most maxBytes that doesn't split a rune
+func cutText(text string, maxBytes int) string {
+	if len(text) <= maxBytes {
+		return text
+	}
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut]
+}
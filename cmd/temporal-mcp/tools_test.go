@@ -0,0 +1,319 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	mcphttp "github.com/metoro-io/mcp-golang/transport/http"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+)
+
+func TestResponseLimit(t *testing.T) {
+	args := WorkflowParams{Params: map[string]string{"order_id": "42"}}
+	bigResult := strings.Repeat("x", 500)
+
+	t.Run("oversized result is truncated with a marker", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runResult: bigResult}
+		handler := withResponseLimit(200, workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, &config.Config{}))
+
+		resp, err := handler(args)
+		require.NoError(t, err)
+		require.Len(t, resp.Content, 1)
+
+		text := resp.Content[0].TextContent.Text
+		require.LessOrEqual(t, len(text), 200)
+		require.True(t, strings.HasPrefix(text, "xxx"))
+		require.True(t, strings.HasSuffix(text, "[truncated: response was 500 bytes, limit is 200 bytes]"))
+	})
+
+	t.Run("small result is untouched", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runResult: "ok"}
+		handler := withResponseLimit(200, workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, &config.Config{}))
+
+		resp, err := handler(args)
+		require.NoError(t, err)
+		require.Equal(t, "ok", resp.Content[0].TextContent.Text)
+	})
+
+	t.Run("no limit by default", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runResult: bigResult}
+		handler := withResponseLimit(0, workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, &config.Config{}))
+
+		resp, err := handler(args)
+		require.NoError(t, err)
+		require.Equal(t, bigResult, resp.Content[0].TextContent.Text)
+	})
+}
+
+func TestTruncateToolResponseAcrossContents(t *testing.T) {
+	resp := mcp.NewToolResponse(mcp.NewTextContent(strings.Repeat("a", 100)), mcp.NewTextContent(strings.Repeat("é", 100)))
+
+	truncated := truncateToolResponse(resp, 250)
+	require.Len(t, truncated.Content, 2)
+	require.Equal(t, strings.Repeat("a", 100), truncated.Content[0].TextContent.Text)
+
+	second := truncated.Content[1].TextContent.Text
+	require.Contains(t, second, "[truncated: response was 300 bytes, limit is 250 bytes]")
+	require.LessOrEqual(t, len(truncated.Content[0].TextContent.Text)+len(second), 250)
+	require.True(t, strings.HasPrefix(second, "é") || strings.HasPrefix(second, "\n"), "must not split a rune")
+}
+
+func TestTruncateToolResponseKeepsLaterNotes(t *testing.T) {
+	resp := mcp.NewToolResponse(
+		mcp.NewTextContent(strings.Repeat("x", 500)),
+		mcp.NewTextContent("Warning: no worker is polling task queue orders"),
+		mcp.NewTextContent("View in Temporal UI: http://localhost:8233/namespaces/default/workflows/order_42"),
+	)
+
+	truncated := truncateToolResponse(resp, 300)
+	require.Len(t, truncated.Content, 3)
+	require.Contains(t, truncated.Content[0].TextContent.Text, "[truncated: response was 627 bytes, limit is 300 bytes]")
+	require.Equal(t, resp.Content[1].TextContent.Text, truncated.Content[1].TextContent.Text)
+	require.Equal(t, resp.Content[2].TextContent.Text, truncated.Content[2].TextContent.Text)
+
+	size := 0
+	for _, content := range truncated.Content {
+		size += len(content.TextContent.Text)
+	}
+	require.LessOrEqual(t, size, 300)
+}
+
+func TestOversizedResultFallsBackToSummary(t *testing.T) {
+	args := WorkflowParams{Params: map[string]string{"order_id": "42"}}
+	cfg := &config.Config{MaxToolResponseBytes: 300}
+	items := make([]any, 100)
+	for i := range items {
+		items[i] = map[string]any{"sku": "sku-" + strings.Repeat("0", 10), "quantity": i}
+	}
+	mockClient := &mockTemporalClient{runResult: items}
+
+	resp, err := wrapToolHandler(cfg, workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, cfg))(args)
+	require.NoError(t, err)
+	require.Len(t, resp.Content, 2)
+
+	var summary resultSummary
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &summary))
+	require.Equal(t, "array", summary.Type)
+	require.Equal(t, 100, summary.Length)
+	require.Equal(t, []string{"quantity", "sku"}, summary.ItemKeys)
+	require.Greater(t, summary.Bytes, 300)
+	require.Contains(t, resp.Content[1].TextContent.Text, "so a summary of it is returned instead")
+
+	t.Run("text results are truncated", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runResult: strings.Repeat("x", 500)}
+		resp, err := wrapToolHandler(cfg, workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, cfg))(args)
+		require.NoError(t, err)
+		require.Len(t, resp.Content, 1)
+		require.Contains(t, resp.Content[0].TextContent.Text, "[truncated: response was 500 bytes, limit is 300 bytes]")
+	})
+}
+
+func TestResponseEnvelope(t *testing.T) {
+	args := WorkflowParams{Params: map[string]string{"order_id": "42"}}
+	envelope := func(resp *mcp.ToolResponse) map[string]any {
+		require.Len(t, resp.Content, 1)
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &decoded))
+		return decoded
+	}
+
+	t.Run("success with JSON data", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runResult: map[string]any{"status": "shipped"}}
+		resp, err := withEnvelope(0, workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, nil))(args)
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"ok": true, "data": map[string]any{"status": "shipped"}}, envelope(resp))
+	})
+
+	t.Run("success with text data", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runResult: "done"}
+		resp, err := withEnvelope(0, workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, nil))(args)
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"ok": true, "data": "done"}, envelope(resp))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runErr: errors.New("boom")}
+		resp, err := withEnvelope(0, workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, nil))(args)
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"ok": false, "error": map[string]any{"message": "Workflow failed: boom"}}, envelope(resp))
+	})
+
+	t.Run("success whose result reads like an error", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runResult: "Error budget: 3 retries left"}
+		resp, err := withEnvelope(0, workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, nil))(args)
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"ok": true, "data": "Error budget: 3 retries left"}, envelope(resp))
+	})
+
+	t.Run("handler error", func(t *testing.T) {
+		failing := func(args WorkflowParams) (*mcp.ToolResponse, error) { return nil, errors.New("decode failed") }
+		resp, err := withEnvelope(0, failing)(args)
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"ok": false, "error": map[string]any{"message": "decode failed"}}, envelope(resp))
+	})
+
+	t.Run("warnings", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runResult: "done"}
+		resp, err := withEnvelope(0, workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, &config.Config{CheckTaskQueuePollers: true}))(args)
+		require.NoError(t, err)
+		decoded := envelope(resp)
+		require.Equal(t, true, decoded["ok"])
+		require.Len(t, decoded["warnings"], 1)
+	})
+}
+
+func TestWrapToolHandler(t *testing.T) {
+	args := WorkflowParams{Params: map[string]string{"order_id": "42"}}
+	bigResult := strings.Repeat("x", 500)
+
+	t.Run("tool errors are sent as responses", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runErr: errors.New("boom")}
+		_, err := workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, nil)(args)
+		var toolErr *toolError
+		require.ErrorAs(t, err, &toolErr)
+
+		resp, err := wrapToolHandler(&config.Config{}, workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, nil))(args)
+		require.NoError(t, err)
+		require.Equal(t, "Workflow failed: boom", resp.Content[0].TextContent.Text)
+	})
+
+	t.Run("the limit covers the envelope", func(t *testing.T) {
+		cfg := &config.Config{ResponseEnvelope: true, MaxToolResponseBytes: 200}
+		mockClient := &mockTemporalClient{runResult: bigResult}
+		resp, err := wrapToolHandler(cfg, workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, cfg))(args)
+		require.NoError(t, err)
+		require.Len(t, resp.Content, 1)
+
+		text := resp.Content[0].TextContent.Text
+		require.LessOrEqual(t, len(text), 200)
+		require.True(t, json.Valid([]byte(text)), text)
+
+		var envelope toolEnvelope
+		require.NoError(t, json.Unmarshal([]byte(text), &envelope))
+		require.True(t, envelope.OK)
+		require.True(t, envelope.Truncated)
+		var data string
+		require.NoError(t, json.Unmarshal(envelope.Data, &data))
+		require.True(t, strings.HasPrefix(data, "xxx"), data)
+		require.Equal(t, []string{"Truncated: response was 521 bytes, limit is 200 bytes"}, envelope.Warnings)
+	})
+
+	t.Run("cut envelopes stay valid JSON", func(t *testing.T) {
+		// Quotes and JSON results encode longer as strings than they are, and errors are cut in their message
+		cfg := &config.Config{ResponseEnvelope: true, MaxToolResponseBytes: 150}
+		results := []*mockTemporalClient{
+			{runResult: strings.Repeat(`"é\`, 100)},
+			{runResult: map[string]any{"items": strings.Split(strings.Repeat("item,", 100), ",")}},
+			{runErr: errors.New(strings.Repeat("boom ", 100))},
+		}
+		for _, mockClient := range results {
+			resp, err := wrapToolHandler(cfg, workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, cfg))(args)
+			require.NoError(t, err)
+			text := resp.Content[0].TextContent.Text
+			require.LessOrEqual(t, len(text), 150)
+			require.True(t, json.Valid([]byte(text)), text)
+			require.Contains(t, text, `"truncated":true`)
+		}
+	})
+}
+
+func TestDegradedModeMessage(t *testing.T) {
+	const message = "Temporal is under maintenance until 14:00 UTC - see https://status.example.com"
+	args := WorkflowParams{Params: map[string]string{"order_id": "42"}}
+
+	t.Run("appended to degraded mode errors", func(t *testing.T) {
+		resp, err := asResponse(withDegradedModeMessage(message, workflowToolHandler("OrderWorkflow", testWorkflow(), nil, nil))(args))
+		require.NoError(t, err)
+		require.Equal(t, "Error: Temporal service is currently unavailable. Please try again later. "+message,
+			resp.Content[0].TextContent.Text)
+
+		resp, err = asResponse(withDegradedModeMessage(message, signalWorkflowHandler(nil, nil))(SignalWorkflowParams{}))
+		require.NoError(t, err)
+		require.Equal(t, "Error: Temporal client is not available for signaling workflows "+message, resp.Content[0].TextContent.Text)
+	})
+
+	t.Run("other responses are untouched", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runErr: errors.New("boom")}
+		resp, err := asResponse(withDegradedModeMessage(message, workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, nil))(args))
+		require.NoError(t, err)
+		require.Equal(t, "Workflow failed: boom", resp.Content[0].TextContent.Text)
+	})
+
+	t.Run("default message without one configured", func(t *testing.T) {
+		resp, err := asResponse(withDegradedModeMessage("", workflowToolHandler("OrderWorkflow", testWorkflow(), nil, nil))(args))
+		require.NoError(t, err)
+		require.Equal(t, "Error: Temporal service is currently unavailable. Please try again later.", resp.Content[0].TextContent.Text)
+	})
+}
+
+func TestGetToolSchemas(t *testing.T) {
+	server := mcp.NewServer(mcphttp.NewHTTPTransport("/mcp"))
+	cfg := &config.Config{Workflows: map[string]config.WorkflowDef{"OrderWorkflow": testWorkflow()}}
+	require.NoError(t, registerWorkflowTools(server, cfg, nil))
+	require.NoError(t, registerGetWorkflowHistoryTool(server, nil, nil, cfg))
+	require.NoError(t, registerPingTool(server, cfg, nil, time.Now()))
+	require.NoError(t, registerGetToolSchemasTool(server, cfg))
+
+	resp, err := getToolSchemasHandler(server)(GetToolSchemasParams{})
+	require.NoError(t, err)
+
+	var document struct {
+		Tools []struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			InputSchema struct {
+				Type       string                    `json:"type"`
+				Properties map[string]map[string]any `json:"properties"`
+			} `json:"inputSchema"`
+		} `json:"tools"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &document))
+
+	var names []string
+	for _, tool := range document.Tools {
+		names = append(names, tool.Name)
+		require.True(t, server.CheckToolRegistered(tool.Name))
+		require.NotEmpty(t, tool.Description)
+		require.Equal(t, "object", tool.InputSchema.Type, tool.Name)
+	}
+	require.Equal(t, []string{"OrderWorkflow", "GetWorkflowHistory", "Ping", "GetToolSchemas"}, names)
+
+	require.Contains(t, document.Tools[0].InputSchema.Properties, "params")
+	require.Contains(t, document.Tools[0].InputSchema.Properties, "force_rerun")
+	require.Contains(t, document.Tools[1].InputSchema.Properties, "workflowId")
+	require.Contains(t, document.Tools[1].InputSchema.Properties, "headEvents")
+	require.Contains(t, document.Tools[1].InputSchema.Properties, "namespace")
+}
+
+func TestToolPrefix(t *testing.T) {
+	server := mcp.NewServer(mcphttp.NewHTTPTransport("/mcp"))
+	cfg := &config.Config{
+		ToolPrefix: "orders_",
+		Workflows:  map[string]config.WorkflowDef{"OrderWorkflow": testWorkflow()},
+	}
+	require.NoError(t, registerWorkflowTools(server, cfg, nil))
+	require.NoError(t, registerGetWorkflowHistoryTool(server, nil, nil, cfg))
+	require.NoError(t, registerPingTool(server, cfg, nil, time.Now()))
+
+	var names []string
+	for _, tool := range registeredTools(server) {
+		names = append(names, tool.Name)
+		require.True(t, server.CheckToolRegistered(tool.Name))
+	}
+	require.Equal(t, []string{"orders_OrderWorkflow", "orders_GetWorkflowHistory", "orders_Ping"}, names)
+	require.False(t, server.CheckToolRegistered("GetWorkflowHistory"))
+
+	// The system prompt refers to workflow tools by their registered names
+	prompt := buildSystemPrompt(cfg)
+	require.Contains(t, prompt, "## orders_OrderWorkflow\n")
+	require.NotContains(t, prompt, "## OrderWorkflow\n")
+
+	cfg.SystemPromptStyle = config.SystemPromptStyleCompact
+	require.Contains(t, buildSystemPrompt(cfg), "- `orders_OrderWorkflow`: ")
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	temporal_enums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/client"
+)
+
+const (
+	// defaultWaitForStatusDuration is how long WaitForStatus waits when the caller doesn't specify a timeout
+	defaultWaitForStatusDuration = 30 * time.Second
+	// maxWaitForStatusDuration bounds how long a single WaitForStatus call may hold the tool call open
+	maxWaitForStatusDuration = 5 * time.Minute
+)
+
+// statusPollInterval is how often WaitForStatus describes the workflow; a variable so tests can shorten it
+var statusPollInterval = time.Second
+
+// WaitForStatusParams are the arguments of the WaitForStatus tool
+type WaitForStatusParams struct {
+	NamespaceParam
+
+	WorkflowID     string `json:"workflowId"`
+	RunID          string `json:"runId"`
+	Status         string `json:"status"`
+	TimeoutSeconds int    `json:"timeoutSeconds"`
+}
+
+// waitForStatusResult is the JSON document returned by the WaitForStatus tool. Status is the last status observed.
+type waitForStatusResult struct {
+	WorkflowID string `json:"workflowId"`
+	RunID      string `json:"runId,omitempty"`
+	Status     string `json:"status"`
+	Matched    bool   `json:"matched"`
+	TimedOut   bool   `json:"timedOut"`
+	Polls      int    `json:"polls"`
+}
+
+// registerWaitForStatusTool registers a tool that waits until a workflow reaches a given status
+func registerWaitForStatusTool(server *mcp.Server, tempClient client.Client, clients namespaceClients, cfg *config.Config) error {
+	desc := fmt.Sprintf("Waits until a workflow reaches the given status (Running, Completed, Failed, Canceled, Terminated, "+
+		"ContinuedAsNew or TimedOut), checking every %s for up to timeoutSeconds (default %d, max %d). Returns the last "+
+		"observed status, and stops early if the workflow closes with a different status. runId is optional - if omitted, "+
+		"this tool watches the %s run of the given workflowId",
+		statusPollInterval, int(defaultWaitForStatusDuration.Seconds()), int(maxWaitForStatusDuration.Seconds()), runSelector(cfg))
+
+	return registerTool(server, cfg, "WaitForStatus", desc+namespaceDescription(cfg), namespacedHandler(tempClient, clients, cfg, waitForStatusHandler))
+}
+
+func waitForStatusHandler(tempClient client.Client, cfg *config.Config) func(args WaitForStatusParams) (*mcp.ToolResponse, error) {
+	return func(args WaitForStatusParams) (*mcp.ToolResponse, error) {
+		// Check if Temporal client is available
+		if tempClient == nil {
+			log.Printf("Error: Temporal client is not available for waiting on workflow statuses")
+			return degradedResponse("Error: Temporal client is not available for waiting on workflow statuses")
+		}
+
+		target, err := parseWorkflowStatus(args.Status)
+		if err != nil {
+			return errorResponse(fmt.Sprintf("Error: %v", err))
+		}
+
+		duration := defaultWaitForStatusDuration
+		if args.TimeoutSeconds > 0 {
+			duration = time.Duration(args.TimeoutSeconds) * time.Second
+		}
+		if duration > maxWaitForStatusDuration {
+			duration = maxWaitForStatusDuration
+		}
+
+		runID, err := resolveRun(context.Background(), tempClient, cfg, args.WorkflowID, args.RunID)
+		if err != nil {
+			msg := fmt.Sprintf("Error: %v", err)
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), duration)
+		defer cancel()
+		ticker := time.NewTicker(statusPollInterval)
+		defer ticker.Stop()
+
+		result := waitForStatusResult{WorkflowID: args.WorkflowID}
+		for {
+			resp, err := tempClient.DescribeWorkflowExecution(ctx, args.WorkflowID, runID)
+			if err != nil && ctx.Err() == nil {
+				msg := fmt.Sprintf("Error: Failed to describe workflow %s: %v", args.WorkflowID, err)
+				log.Print(msg)
+				return errorResponse(msg)
+			}
+			if err == nil {
+				result.Polls++
+				info := resp.GetWorkflowExecutionInfo()
+				status := info.GetStatus()
+				result.RunID = info.GetExecution().GetRunId()
+				result.Status = status.String()
+				result.Matched = status == target
+				// A closed workflow's status can't change any more
+				if result.Matched || status != temporal_enums.WORKFLOW_EXECUTION_STATUS_RUNNING {
+					break
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				result.TimedOut = true
+			case <-ticker.C:
+			}
+			if result.TimedOut {
+				break
+			}
+		}
+
+		bytes, err := json.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResponse(mcp.NewTextContent(string(bytes))), nil
+	}
+}
+
+// parseWorkflowStatus accepts a workflow status in any case, as its short name ("Completed", "COMPLETED") or its full
+// enum name ("WORKFLOW_EXECUTION_STATUS_COMPLETED")
+func parseWorkflowStatus(s string) (temporal_enums.WorkflowExecutionStatus, error) {
+	normalize := func(name string) string {
+		return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+	}
+	wanted := strings.TrimPrefix(normalize(s), "workflowexecutionstatus")
+	var names []string
+	for value := range temporal_enums.WorkflowExecutionStatus_name {
+		status := temporal_enums.WorkflowExecutionStatus(value)
+		if status == temporal_enums.WORKFLOW_EXECUTION_STATUS_UNSPECIFIED {
+			continue
+		}
+		if normalize(status.String()) == wanted {
+			return status, nil
+		}
+		names = append(names, status.String())
+	}
+	slices.Sort(names)
+	return 0, fmt.Errorf("unknown workflow status %q - must be one of %s", s, strings.Join(names, ", "))
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/common/v1"
+	temporal_enums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+// describeWithStatus returns a DescribeWorkflowExecution response for a run with the given status
+func describeWithStatus(status temporal_enums.WorkflowExecutionStatus) *workflowservice.DescribeWorkflowExecutionResponse {
+	return &workflowservice.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &workflow.WorkflowExecutionInfo{
+			Execution: &common.WorkflowExecution{WorkflowId: "order_1", RunId: "run-1"},
+			Status:    status,
+		},
+	}
+}
+
+func TestWaitForStatus(t *testing.T) {
+	defer func(interval time.Duration) { statusPollInterval = interval }(statusPollInterval)
+	statusPollInterval = 10 * time.Millisecond
+
+	wait := func(t *testing.T, mockClient *mockTemporalClient, args WaitForStatusParams) waitForStatusResult {
+		args.WorkflowID = "order_1"
+		resp, err := waitForStatusHandler(mockClient, nil)(args)
+		require.NoError(t, err)
+		var result waitForStatusResult
+		require.NoError(t, json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &result), resp.Content[0].TextContent.Text)
+		return result
+	}
+
+	t.Run("reaches the target status after one poll", func(t *testing.T) {
+		mockClient := &mockTemporalClient{describeSequence: []*workflowservice.DescribeWorkflowExecutionResponse{
+			describeWithStatus(temporal_enums.WORKFLOW_EXECUTION_STATUS_RUNNING),
+			describeWithStatus(temporal_enums.WORKFLOW_EXECUTION_STATUS_COMPLETED),
+		}}
+		result := wait(t, mockClient, WaitForStatusParams{Status: "COMPLETED", TimeoutSeconds: 5})
+		require.Equal(t, waitForStatusResult{WorkflowID: "order_1", RunID: "run-1", Status: "Completed", Matched: true, Polls: 2}, result)
+	})
+
+	t.Run("stops when the workflow closes with another status", func(t *testing.T) {
+		mockClient := &mockTemporalClient{describeResponse: describeWithStatus(temporal_enums.WORKFLOW_EXECUTION_STATUS_FAILED)}
+		result := wait(t, mockClient, WaitForStatusParams{Status: "Completed", TimeoutSeconds: 5})
+		require.Equal(t, "Failed", result.Status)
+		require.False(t, result.Matched)
+		require.False(t, result.TimedOut)
+		require.Equal(t, 1, result.Polls)
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		mockClient := &mockTemporalClient{describeResponse: describeWithStatus(temporal_enums.WORKFLOW_EXECUTION_STATUS_RUNNING)}
+		result := wait(t, mockClient, WaitForStatusParams{Status: "WORKFLOW_EXECUTION_STATUS_COMPLETED", TimeoutSeconds: 1})
+		require.Equal(t, "Running", result.Status)
+		require.True(t, result.TimedOut)
+		require.False(t, result.Matched)
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		resp, err := asResponse(waitForStatusHandler(&mockTemporalClient{}, nil)(WaitForStatusParams{WorkflowID: "order_1", Status: "done"}))
+		require.NoError(t, err)
+		require.Equal(t, `Error: unknown workflow status "done" - must be one of Canceled, Completed, ContinuedAsNew, Failed, Running, Terminated, TimedOut`,
+			resp.Content[0].TextContent.Text)
+	})
+}
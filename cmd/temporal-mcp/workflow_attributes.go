@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+)
+
+// GetWorkflowAttributesParams are the arguments of the GetWorkflowAttributes tool
+type GetWorkflowAttributesParams struct {
+	NamespaceParam
+
+	WorkflowID string `json:"workflowId"`
+	RunID      string `json:"runId"`
+}
+
+// workflowAttributesResult is the JSON returned by the GetWorkflowAttributes tool
+type workflowAttributesResult struct {
+	WorkflowID       string                              `json:"workflowId"`
+	RunID            string                              `json:"runId"`
+	WorkflowType     string                              `json:"workflowType"`
+	Status           string                              `json:"status"`
+	TaskQueue        string                              `json:"taskQueue"`
+	StartTime        *time.Time                          `json:"startTime,omitempty"`
+	CloseTime        *time.Time                          `json:"closeTime,omitempty"`
+	HistoryLength    int64                               `json:"historyLength"`
+	Memo             map[string]interface{}              `json:"memo"`
+	SearchAttributes map[string]searchAttributeValueJSON `json:"searchAttributes"`
+}
+
+// searchAttributeValueJSON is a decoded search attribute together with its registered type (e.g. Keyword, Int)
+type searchAttributeValueJSON struct {
+	Type  string      `json:"type,omitempty"`
+	Value interface{} `json:"value"`
+}
+
+// registerGetWorkflowAttributesTool registers a tool that returns a workflow's memo, search attributes and execution info
+func registerGetWorkflowAttributesTool(server *mcp.Server, tempClient client.Client, clients namespaceClients, cfg *config.Config) error {
+	desc := "Gets a workflow's memo, search attributes, and basic execution info (type, status, task queue, start/close time, " +
+		"history length). runId is optional - if omitted, this tool describes the " + runSelector(cfg) + " run of the given workflowId"
+
+	return registerTool(server, cfg, "GetWorkflowAttributes", desc+namespaceDescription(cfg), namespacedHandler(tempClient, clients, cfg, getWorkflowAttributesHandler))
+}
+
+func getWorkflowAttributesHandler(tempClient client.Client, cfg *config.Config) func(args GetWorkflowAttributesParams) (*mcp.ToolResponse, error) {
+	return func(args GetWorkflowAttributesParams) (*mcp.ToolResponse, error) {
+		// Check if Temporal client is available
+		if tempClient == nil {
+			log.Printf("Error: Temporal client is not available for getting workflow attributes")
+			return degradedResponse("Error: Temporal client is not available for getting workflow attributes")
+		}
+
+		runID, err := resolveRun(context.Background(), tempClient, cfg, args.WorkflowID, args.RunID)
+		if err != nil {
+			msg := fmt.Sprintf("Error: %v", err)
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		resp, err := tempClient.DescribeWorkflowExecution(context.Background(), args.WorkflowID, runID)
+		if err != nil {
+			msg := fmt.Sprintf("Error: Failed to describe workflow %s: %v", args.WorkflowID, err)
+			var notFound *serviceerror.NotFound
+			if errors.As(err, &notFound) {
+				msg = fmt.Sprintf("Error: Workflow %s not found", args.WorkflowID)
+			}
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		info := resp.GetWorkflowExecutionInfo()
+		result := workflowAttributesResult{
+			WorkflowID:       info.GetExecution().GetWorkflowId(),
+			RunID:            info.GetExecution().GetRunId(),
+			WorkflowType:     info.GetType().GetName(),
+			Status:           info.GetStatus().String(),
+			TaskQueue:        info.GetTaskQueue(),
+			HistoryLength:    info.GetHistoryLength(),
+			Memo:             map[string]interface{}{},
+			SearchAttributes: map[string]searchAttributeValueJSON{},
+		}
+		if info.GetStartTime() != nil {
+			startTime := info.GetStartTime().AsTime()
+			result.StartTime = &startTime
+		}
+		if info.GetCloseTime() != nil {
+			closeTime := info.GetCloseTime().AsTime()
+			result.CloseTime = &closeTime
+		}
+
+		dataConverter := converter.GetDefaultDataConverter()
+		for key, payload := range info.GetMemo().GetFields() {
+			var value interface{}
+			if err := dataConverter.FromPayload(payload, &value); err != nil {
+				return nil, fmt.Errorf("failed to decode memo %s: %w", key, err)
+			}
+			result.Memo[key] = value
+		}
+		for key, payload := range info.GetSearchAttributes().GetIndexedFields() {
+			value, err := decodeSearchAttribute(payload)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode search attribute %s: %w", key, err)
+			}
+			result.SearchAttributes[key] = value
+		}
+
+		bytes, err := json.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResponse(mcp.NewTextContent(string(bytes))), nil
+	}
+}
+
+// decodeSearchAttribute decodes a search attribute payload. Typed search attributes are JSON with their type (Keyword,
+// Int, Datetime, ...) in the "type" metadata. Numbers are kept as written, so large Int values keep their precision.
+func decodeSearchAttribute(payload *common.Payload) (searchAttributeValueJSON, error) {
+	attribute := searchAttributeValueJSON{Type: string(payload.GetMetadata()["type"])}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload.GetData()))
+	decoder.UseNumber()
+	if err := decoder.Decode(&attribute.Value); err != nil {
+		// Not JSON - fall back to the data converter (e.g. binary payloads)
+		if err := converter.GetDefaultDataConverter().FromPayload(payload, &attribute.Value); err != nil {
+			return attribute, err
+		}
+	}
+	return attribute, nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/common/v1"
+	temporal_enums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/converter"
+)
+
+func TestGetWorkflowAttributes(t *testing.T) {
+	t.Run("memo and search attributes are decoded", func(t *testing.T) {
+		memo, err := converter.GetDefaultDataConverter().ToPayload(map[string]string{"requestedBy": "alice"})
+		require.NoError(t, err)
+		customerID := &common.Payload{
+			Metadata: map[string][]byte{"encoding": []byte("json/plain"), "type": []byte("Keyword")},
+			Data:     []byte(`"cust-42"`),
+		}
+		bigInt := &common.Payload{
+			Metadata: map[string][]byte{"encoding": []byte("json/plain"), "type": []byte("Int")},
+			Data:     []byte(`9007199254740993`),
+		}
+
+		mockClient := &mockTemporalClient{describeResponse: &workflowservice.DescribeWorkflowExecutionResponse{
+			WorkflowExecutionInfo: &workflow.WorkflowExecutionInfo{
+				Execution:        &common.WorkflowExecution{WorkflowId: "order_1", RunId: "run-1"},
+				Type:             &common.WorkflowType{Name: "OrderWorkflow"},
+				Status:           temporal_enums.WORKFLOW_EXECUTION_STATUS_RUNNING,
+				TaskQueue:        "orders",
+				HistoryLength:    12,
+				Memo:             &common.Memo{Fields: map[string]*common.Payload{"context": memo}},
+				SearchAttributes: &common.SearchAttributes{IndexedFields: map[string]*common.Payload{"CustomerId": customerID, "Attempt": bigInt}},
+			},
+		}}
+
+		resp, err := getWorkflowAttributesHandler(mockClient, nil)(GetWorkflowAttributesParams{WorkflowID: "order_1"})
+		require.NoError(t, err)
+		require.Equal(t, "order_1", mockClient.lastDescribeID)
+		require.JSONEq(t, `{
+			"workflowId": "order_1",
+			"runId": "run-1",
+			"workflowType": "OrderWorkflow",
+			"status": "Running",
+			"taskQueue": "orders",
+			"historyLength": 12,
+			"memo": {"context": {"requestedBy": "alice"}},
+			"searchAttributes": {
+				"CustomerId": {"type": "Keyword", "value": "cust-42"},
+				"Attempt": {"type": "Int", "value": 9007199254740993}
+			}
+		}`, resp.Content[0].TextContent.Text)
+		require.Contains(t, resp.Content[0].TextContent.Text, `"value":9007199254740993`, "large Int values keep their precision")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockClient := &mockTemporalClient{describeErr: serviceerror.NewNotFound("workflow not found")}
+		resp, err := asResponse(getWorkflowAttributesHandler(mockClient, nil)(GetWorkflowAttributesParams{WorkflowID: "missing"}))
+		require.NoError(t, err)
+		require.Equal(t, "Error: Workflow missing not found", resp.Content[0].TextContent.Text)
+	})
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/mocksi/temporal-mcp/internal/config"
+)
+
+// workflowIDOptions tunes how workflow IDs are computed from recipes
+type workflowIDOptions struct {
+	// HashSalt is mixed into every {{ hash }} in the recipe
+	HashSalt string
+	// MissingKey is one of the config.MissingKey* modes; empty behaves like config.MissingKeyDefault
+	MissingKey string
+	// MissingValue replaces missing or empty values when MissingKey is config.MissingKeyReplace
+	MissingValue string
+	// Fallback is one of the config.WorkflowIDFallback* modes; empty behaves like
+	// config.WorkflowIDFallbackDeterministic
+	Fallback string
+	// Timeout bounds how long a recipe may take to render; 0 means defaultWorkflowIDRecipeTimeout
+	Timeout time.Duration
+	// MaxLength bounds the workflow ID, in bytes; 0 means maxWorkflowIDLength
+	MaxLength int
+}
+
+// newWorkflowIDOptions builds the workflow ID options from configuration. cfg may be nil. LoadConfig rejects an invalid
+// workflowIDRecipeTimeout, so one that doesn't parse here falls back to the default.
+func newWorkflowIDOptions(cfg *config.Config) workflowIDOptions {
+	if cfg == nil {
+		return workflowIDOptions{}
+	}
+	timeout, _ := time.ParseDuration(cfg.WorkflowIDRecipeTimeout)
+	return workflowIDOptions{
+		HashSalt:     cfg.WorkflowIDHashSalt,
+		MissingKey:   cfg.WorkflowIDMissingKey,
+		MissingValue: cfg.WorkflowIDMissingValue,
+		Fallback:     cfg.WorkflowIDFallback,
+		Timeout:      timeout,
+		MaxLength:    cfg.MaxWorkflowIDLength,
+	}
+}
+
+// maxWorkflowIDLength is Temporal's default limit (limit.maxIDLength) on workflow ID length, in bytes
+const maxWorkflowIDLength = 1000
+
+// maxRenderedWorkflowIDBytes bounds a recipe's output before truncation. Output this large means the recipe is broken
+// (e.g. a runaway range), so it's rejected rather than truncated.
+const maxRenderedWorkflowIDBytes = 64 * 1024
+
+// defaultWorkflowIDRecipeTimeout bounds how long a recipe may take to render unless configured otherwise
+const defaultWorkflowIDRecipeTimeout = time.Second
+
+// fallbackWorkflowIDNamespace is the UUIDv5 namespace of deterministic fallback workflow IDs
+var fallbackWorkflowIDNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://github.com/mocksi/temporal-mcp/workflow-id"))
+
+// fallbackWorkflowID returns the ID of a workflow that has no workflow ID recipe. By default it's a UUIDv5 derived
+// from the workflow name, the hash salt and the params, so identical calls dedup like they would with a recipe;
+// config.WorkflowIDFallbackRandom opts into a random UUIDv4 instead.
+func fallbackWorkflowID(name string, params map[string]string, opts workflowIDOptions) (string, error) {
+	switch opts.Fallback {
+	case "", config.WorkflowIDFallbackDeterministic:
+	case config.WorkflowIDFallbackRandom:
+		return uuid.NewString(), nil
+	default:
+		return "", fmt.Errorf("unsupported workflowIDFallback mode %q", opts.Fallback)
+	}
+
+	// important: json.Marshal sorts map keys
+	bytes, err := json.Marshal(params)
+	if err != nil {
+		return "", err
+	}
+	data := make([]byte, 0, len(name)+len(opts.HashSalt)+len(bytes)+2)
+	data = append(append(data, name...), 0)
+	data = append(append(data, opts.HashSalt...), 0)
+	data = append(data, bytes...)
+	return uuid.NewSHA1(fallbackWorkflowIDNamespace, data).String(), nil
+}
+
+// computeWorkflowID renders the workflow's WorkflowIDRecipe against the given params. The output of every action is
+// escaped so that caller-supplied values can't introduce characters that are unsafe in workflow IDs, and the result is
+// shortened to opts.MaxLength (Temporal's maximum ID length by default). Literal text in the recipe is left alone.
+//
+// By default a param the recipe references but the caller didn't provide renders as text/template's "<no value>".
+// opts.MissingKey can instead make that an error, or replace missing/empty values with opts.MissingValue.
+//
+// input is the workflow input as the workflow's input schema types it, or nil without a schema; a bare {{ hash }}
+// hashes it instead of the string params, so numbers and objects are hashed as the workflow receives them.
+func computeWorkflowID(workflow config.WorkflowDef, params map[string]string, input map[string]interface{}, opts workflowIDOptions) (string, error) {
+	tmpl := template.New("id_recipe")
+
+	escape := escapeWorkflowIDPart
+	switch opts.MissingKey {
+	case "", config.MissingKeyDefault:
+	case config.MissingKeyError:
+		tmpl.Option("missingkey=error")
+	case config.MissingKeyReplace:
+		escape = func(value any) string {
+			if value == nil || value == "" {
+				return escapeWorkflowIDPart(opts.MissingValue)
+			}
+			return escapeWorkflowIDPart(value)
+		}
+	default:
+		return "", fmt.Errorf("unsupported workflowIDMissingKey mode %q", opts.MissingKey)
+	}
+
+	if input == nil {
+		input = paramValues(params)
+	}
+	tmpl.Funcs(template.FuncMap{
+		"hash": func(paramsToHash ...any) (string, error) {
+			return hashWorkflowArgs(opts.HashSalt, input, paramsToHash...)
+		},
+		escapeIDFunc: escape,
+	})
+	if _, err := tmpl.Parse(workflow.WorkflowIDRecipe); err != nil {
+		return "", err
+	}
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil {
+			escapeTemplateActions(t.Tree, t.Tree.Root)
+		}
+	}
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultWorkflowIDRecipeTimeout
+	}
+	writer := &recipeWriter{limit: maxRenderedWorkflowIDBytes, deadline: time.Now().Add(timeout)}
+
+	// Execute can't be cancelled, so it runs in its own goroutine and the call stops waiting for it after the timeout.
+	// Only the writer stops the goroutine: its writes fail after the deadline or past maxRenderedWorkflowIDBytes, which
+	// ends a recipe that keeps producing output. A recipe that loops without output keeps running in the background
+	// until it's done, so the writer's limits - not the timeout - are what bound the work a recipe can do.
+	done := make(chan error, 1)
+	go func() { done <- tmpl.Execute(writer, params) }()
+	var err error
+	select {
+	case err = <-done:
+	case <-time.After(timeout):
+		err = errRecipeTimeout
+	}
+	if errors.Is(err, errRecipeTimeout) {
+		return "", fmt.Errorf("workflow ID recipe took longer than %s to render", timeout)
+	}
+	if errors.Is(err, errRecipeOutputTooLarge) {
+		return "", fmt.Errorf("workflow ID recipe produced more than %d bytes", maxRenderedWorkflowIDBytes)
+	}
+	if err != nil {
+		if opts.MissingKey == config.MissingKeyError {
+			return "", fmt.Errorf("workflow ID recipe references a parameter that was not provided: %w", err)
+		}
+		return "", err
+	}
+
+	maxLength := opts.MaxLength
+	if maxLength <= 0 {
+		maxLength = maxWorkflowIDLength
+	}
+	return shortenWorkflowID(writer.String(), maxLength), nil
+}
+
+var (
+	errRecipeTimeout        = errors.New("workflow ID recipe timed out")
+	errRecipeOutputTooLarge = errors.New("workflow ID recipe output too large")
+)
+
+// recipeWriter collects a recipe's output, failing writes beyond limit bytes or after the deadline
+type recipeWriter struct {
+	strings.Builder
+	limit    int
+	deadline time.Time
+}
+
+func (w *recipeWriter) Write(p []byte) (int, error) {
+	if time.Now().After(w.deadline) {
+		return 0, errRecipeTimeout
+	}
+	if w.Len()+len(p) > w.limit {
+		return 0, errRecipeOutputTooLarge
+	}
+	return w.Builder.Write(p)
+}
+
+// escapeIDFunc is the template function appended to every action of a workflow ID recipe
+const escapeIDFunc = "_escapeWorkflowIDPart"
+
+// escapeTemplateActions appends the escape function to the pipeline of every action that produces output, much like
+// html/template does. Escaping the output (rather than the params) keeps {{ hash .x }} hashing the original values.
+func escapeTemplateActions(tree *parse.Tree, node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			escapeTemplateActions(tree, child)
+		}
+	case *parse.ActionNode:
+		// Variable declarations ({{ $x := ... }}) don't produce output
+		if len(n.Pipe.Decl) > 0 {
+			return
+		}
+		ident := parse.NewIdentifier(escapeIDFunc).SetTree(tree).SetPos(n.Pos)
+		n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{NodeType: parse.NodeCommand, Pos: n.Pos, Args: []parse.Node{ident}})
+	case *parse.IfNode:
+		escapeTemplateActions(tree, n.List)
+		escapeTemplateActions(tree, n.ElseList)
+	case *parse.RangeNode:
+		escapeTemplateActions(tree, n.List)
+		escapeTemplateActions(tree, n.ElseList)
+	case *parse.WithNode:
+		escapeTemplateActions(tree, n.List)
+		escapeTemplateActions(tree, n.ElseList)
+	}
+}
+
+// escapeWorkflowIDPart renders a template value and replaces runes that don't belong in a workflow ID (control
+// characters, whitespace, and characters that break Temporal UI/CLI paths) with underscores. Missing values render as
+// "<no value>", exactly as text/template prints them.
+func escapeWorkflowIDPart(value any) string {
+	if value == nil {
+		return "<no value>"
+	}
+	return strings.Map(func(r rune) rune {
+		if !unicode.IsPrint(r) || unicode.IsSpace(r) || strings.ContainsRune(`/\?#%`, r) {
+			return '_'
+		}
+		return r
+	}, fmt.Sprint(value))
+}
+
+// shortenWorkflowID bounds id to maxBytes bytes. An ID that's too long keeps as much of its beginning as fits, followed
+// by a hash of the full ID, so that IDs differing only in the cut-off part stay distinct.
+func shortenWorkflowID(id string, maxBytes int) string {
+	if len(id) <= maxBytes {
+		return id
+	}
+	sum := sha256.Sum256([]byte(id))
+	suffix := "_" + hex.EncodeToString(sum[:8])
+	if maxBytes <= len(suffix) {
+		return truncateWorkflowID(suffix[1:], maxBytes)
+	}
+	shortened := truncateWorkflowID(id, maxBytes-len(suffix)) + suffix
+	log.Printf("Workflow ID is %d bytes, over the limit of %d - shortened it to %s", len(id), maxBytes, shortened)
+	return shortened
+}
+
+// truncateWorkflowID cuts id down to at most maxBytes bytes without splitting a multi-byte rune
+func truncateWorkflowID(id string, maxBytes int) string {
+	if len(id) <= maxBytes {
+		return id
+	}
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(id[cut]) {
+		cut--
+	}
+	return id[:cut]
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+)
+
+// workflowTimeouts are the run and execution timeouts a caller asked for, after applying the configured ceiling. Zero
+// leaves the server default (no timeout).
+type workflowTimeouts struct {
+	run       time.Duration
+	execution time.Duration
+	warnings  []string
+}
+
+// callerWorkflowTimeouts parses the caller's run_timeout and execution_timeout. Values above ceiling (the parsed
+// maxWorkflowTimeout, or 0 for none) are clamped to it, with a warning.
+func callerWorkflowTimeouts(args WorkflowParams, ceiling time.Duration) (workflowTimeouts, error) {
+	if args.RunTimeout == "" && args.ExecutionTimeout == "" {
+		return workflowTimeouts{}, nil
+	}
+
+	var timeouts workflowTimeouts
+	parse := func(name string, value string) (time.Duration, error) {
+		if value == "" {
+			return 0, nil
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("%s must be a duration like \"10m\" or \"1h30m\", got %q", name, value)
+		}
+		if d <= 0 {
+			return 0, fmt.Errorf("%s must be positive, got %q", name, value)
+		}
+		if ceiling > 0 && d > ceiling {
+			warning := fmt.Sprintf("Warning: %s %s exceeds the maximum of %s - using %s", name, d, ceiling, ceiling)
+			log.Print(warning)
+			timeouts.warnings = append(timeouts.warnings, warning)
+			return ceiling, nil
+		}
+		return d, nil
+	}
+
+	var err error
+	if timeouts.run, err = parse("run_timeout", args.RunTimeout); err != nil {
+		return workflowTimeouts{}, err
+	}
+	if timeouts.execution, err = parse("execution_timeout", args.ExecutionTimeout); err != nil {
+		return workflowTimeouts{}, err
+	}
+	return timeouts, nil
+}
+
+// applyConfiguredTimeouts fills the timeouts the caller left unset from the workflow's runTimeout and executionTimeout
+func applyConfiguredTimeouts(timeouts *workflowTimeouts, workflow config.WorkflowDef) error {
+	parse := func(name string, value string, target *time.Duration) error {
+		if value == "" || *target != 0 {
+			return nil
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", name, value, err)
+		}
+		*target = d
+		return nil
+	}
+	if err := parse("runTimeout", workflow.RunTimeout, &timeouts.run); err != nil {
+		return err
+	}
+	return parse("executionTimeout", workflow.ExecutionTimeout, &timeouts.execution)
+}
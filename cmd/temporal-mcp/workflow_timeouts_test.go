@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowToolCallerTimeouts(t *testing.T) {
+	cfg := &config.Config{MaxWorkflowTimeout: "1h"}
+	params := ParamsMap{"order_id": "42"}
+
+	t.Run("within the cap", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runResult: "ok"}
+		args := WorkflowParams{Params: params, RunTimeout: "10m", ExecutionTimeout: "30m"}
+		resp, err := workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, cfg)(args)
+		require.NoError(t, err)
+		require.Len(t, resp.Content, 1)
+		require.Equal(t, 10*time.Minute, mockClient.lastStartOptions.WorkflowRunTimeout)
+		require.Equal(t, 30*time.Minute, mockClient.lastStartOptions.WorkflowExecutionTimeout)
+	})
+
+	t.Run("above the cap is clamped with a warning", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runResult: "ok"}
+		args := WorkflowParams{Params: params, RunTimeout: "3h"}
+		resp, err := workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, cfg)(args)
+		require.NoError(t, err)
+		require.Equal(t, time.Hour, mockClient.lastStartOptions.WorkflowRunTimeout)
+		require.Zero(t, mockClient.lastStartOptions.WorkflowExecutionTimeout)
+		require.Len(t, resp.Content, 2)
+		require.Equal(t, "Warning: run_timeout 3h0m0s exceeds the maximum of 1h0m0s - using 1h0m0s", resp.Content[1].TextContent.Text)
+	})
+
+	t.Run("unset leaves the server default", func(t *testing.T) {
+		mockClient := &mockTemporalClient{runResult: "ok"}
+		_, err := workflowToolHandler("OrderWorkflow", testWorkflow(), mockClient, cfg)(WorkflowParams{Params: params})
+		require.NoError(t, err)
+		require.Zero(t, mockClient.lastStartOptions.WorkflowRunTimeout)
+		require.Zero(t, mockClient.lastStartOptions.WorkflowExecutionTimeout)
+	})
+
+	t.Run("invalid values are rejected", func(t *testing.T) {
+		// The missing-params hint is about params, not timeouts, so it isn't given
+		workflow := testWorkflow()
+		workflow.ErrorHints.MissingParams = "Ask the user for the order ID"
+		for _, value := range []string{"soon", "-5m", "0s"} {
+			mockClient := &mockTemporalClient{runResult: "ok"}
+			resp, err := asResponse(workflowToolHandler("OrderWorkflow", workflow, mockClient, cfg)(WorkflowParams{Params: params, ExecutionTimeout: value}))
+			require.NoError(t, err)
+			require.Contains(t, resp.Content[0].TextContent.Text, "Error: Invalid timeout for workflow OrderWorkflow: execution_timeout")
+			require.NotContains(t, resp.Content[0].TextContent.Text, workflow.ErrorHints.MissingParams)
+			require.Empty(t, mockClient.lastStartOptions.ID, "workflow must not be started")
+		}
+	})
+}
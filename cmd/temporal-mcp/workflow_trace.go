@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	mcp "github.com/metoro-io/mcp-golang"
+	"github.com/mocksi/temporal-mcp/internal/config"
+	temporal_enums "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/history/v1"
+	"go.temporal.io/sdk/client"
+)
+
+// GetWorkflowTraceParams are the arguments of the GetWorkflowTrace tool
+type GetWorkflowTraceParams struct {
+	NamespaceParam
+
+	WorkflowID string `json:"workflowId"`
+	RunID      string `json:"runId"`
+}
+
+// workflowTrace is the JSON returned by the GetWorkflowTrace tool
+type workflowTrace struct {
+	WorkflowID string     `json:"workflowId"`
+	RunID      string     `json:"runId"`
+	Root       *traceSpan `json:"root"`
+}
+
+// traceSpan is one step of a workflow in an OpenTelemetry-like shape. Span IDs are the ID of the event that opened the
+// span. EndTime and Duration are unset while the span is still open.
+type traceSpan struct {
+	SpanID       string       `json:"spanId"`
+	ParentSpanID string       `json:"parentSpanId,omitempty"`
+	Name         string       `json:"name"`
+	Kind         string       `json:"kind"` // workflow, activity, childWorkflow or timer
+	Status       string       `json:"status"`
+	StartTime    time.Time    `json:"startTime"`
+	EndTime      *time.Time   `json:"endTime,omitempty"`
+	Duration     string       `json:"duration,omitempty"`
+	Attempt      int32        `json:"attempt,omitempty"`
+	Children     []*traceSpan `json:"children,omitempty"`
+}
+
+// Kinds of trace spans
+const (
+	spanKindWorkflow      = "workflow"
+	spanKindActivity      = "activity"
+	spanKindChildWorkflow = "childWorkflow"
+	spanKindTimer         = "timer"
+)
+
+// registerGetWorkflowTraceTool registers a tool that returns a workflow history as a span tree
+func registerGetWorkflowTraceTool(server *mcp.Server, tempClient client.Client, clients namespaceClients, cfg *config.Config) error {
+	desc := "Gets the history of a workflow run as a trace: a span tree with the workflow as root and its activities, child " +
+		"workflows and timers as child spans, each with a start time, end time, duration and status. Use it to see where " +
+		"a workflow spent its time (a waterfall view) without reading every history event. runId is optional - if " +
+		"omitted, this tool traces the " + runSelector(cfg) + " run of the given workflowId"
+
+	return registerTool(server, cfg, "GetWorkflowTrace", desc+namespaceDescription(cfg), namespacedHandler(tempClient, clients, cfg, getWorkflowTraceHandler))
+}
+
+func getWorkflowTraceHandler(tempClient client.Client, cfg *config.Config) func(args GetWorkflowTraceParams) (*mcp.ToolResponse, error) {
+	retry := newRetryPolicy(cfg.Temporal.RetryOptions)
+
+	return func(args GetWorkflowTraceParams) (*mcp.ToolResponse, error) {
+		// Check if Temporal client is available
+		if tempClient == nil {
+			log.Printf("Error: Temporal client is not available for getting workflow traces")
+			return degradedResponse("Error: Temporal client is not available for getting workflow traces")
+		}
+
+		runID, err := resolveRun(context.Background(), tempClient, cfg, args.WorkflowID, args.RunID)
+		if err != nil {
+			msg := fmt.Sprintf("Error: %v", err)
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		events, fetchErr := fetchHistoryEvents(context.Background(), tempClient, args.WorkflowID, runID, retry)
+		if fetchErr != nil && len(events) == 0 {
+			msg := fmt.Sprintf("Error: Failed to get the history of workflow %s: %v", args.WorkflowID, fetchErr)
+			log.Print(msg)
+			return errorResponse(msg)
+		}
+
+		root := buildWorkflowTrace(events)
+		if root == nil {
+			return errorResponse(fmt.Sprintf(
+				"Error: The history of workflow %s has no WorkflowExecutionStarted event", args.WorkflowID,
+			))
+		}
+
+		bytes, err := json.Marshal(workflowTrace{WorkflowID: args.WorkflowID, RunID: runID, Root: root})
+		if err != nil {
+			return nil, err
+		}
+		if fetchErr != nil {
+			msg := fmt.Sprintf("Warning: Failed to get the %dth history event: %v - the trace covers only the events before it, "+
+				"so later spans may be missing or shown as still running", len(events), fetchErr)
+			log.Print(msg)
+			return mcp.NewToolResponse(mcp.NewTextContent(string(bytes)), mcp.NewTextContent(msg)), nil
+		}
+		return mcp.NewToolResponse(mcp.NewTextContent(string(bytes))), nil
+	}
+}
+
+// buildWorkflowTrace turns a history into a span tree, or returns nil if the history has no start event. Close events
+// find their span through the ID of the event that opened it (scheduled, initiated or timer started).
+func buildWorkflowTrace(events []*history.HistoryEvent) *traceSpan {
+	var root *traceSpan
+	spans := map[int64]*traceSpan{}
+
+	open := func(event *history.HistoryEvent, kind string, name string) {
+		if root == nil {
+			return
+		}
+		span := &traceSpan{
+			SpanID:       strconv.FormatInt(event.GetEventId(), 10),
+			ParentSpanID: root.SpanID,
+			Name:         name,
+			Kind:         kind,
+			Status:       "Running",
+			StartTime:    event.GetEventTime().AsTime(),
+		}
+		spans[event.GetEventId()] = span
+		root.Children = append(root.Children, span)
+	}
+	closeSpan := func(span *traceSpan, event *history.HistoryEvent, status string) {
+		if span == nil {
+			return
+		}
+		span.Status = status
+		span.end(event.GetEventTime().AsTime())
+	}
+
+	for _, event := range events {
+		eventType := event.GetEventType()
+		if root == nil {
+			if eventType != temporal_enums.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED {
+				continue
+			}
+			root = &traceSpan{
+				SpanID:    strconv.FormatInt(event.GetEventId(), 10),
+				Name:      event.GetWorkflowExecutionStartedEventAttributes().GetWorkflowType().GetName(),
+				Kind:      spanKindWorkflow,
+				Status:    "Running",
+				StartTime: event.GetEventTime().AsTime(),
+			}
+			continue
+		}
+		if isWorkflowCloseEvent(eventType) {
+			closeSpan(root, event, workflowStatusName(eventType))
+			continue
+		}
+
+		switch eventType {
+		case temporal_enums.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
+			open(event, spanKindActivity, event.GetActivityTaskScheduledEventAttributes().GetActivityType().GetName())
+		case temporal_enums.EVENT_TYPE_ACTIVITY_TASK_STARTED:
+			attrs := event.GetActivityTaskStartedEventAttributes()
+			if span := spans[attrs.GetScheduledEventId()]; span != nil {
+				span.Attempt = attrs.GetAttempt()
+			}
+		case temporal_enums.EVENT_TYPE_ACTIVITY_TASK_COMPLETED:
+			closeSpan(spans[event.GetActivityTaskCompletedEventAttributes().GetScheduledEventId()], event, "Completed")
+		case temporal_enums.EVENT_TYPE_ACTIVITY_TASK_FAILED:
+			closeSpan(spans[event.GetActivityTaskFailedEventAttributes().GetScheduledEventId()], event, "Failed")
+		case temporal_enums.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT:
+			closeSpan(spans[event.GetActivityTaskTimedOutEventAttributes().GetScheduledEventId()], event, "TimedOut")
+		case temporal_enums.EVENT_TYPE_ACTIVITY_TASK_CANCELED:
+			closeSpan(spans[event.GetActivityTaskCanceledEventAttributes().GetScheduledEventId()], event, "Canceled")
+
+		case temporal_enums.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_INITIATED:
+			open(event, spanKindChildWorkflow, event.GetStartChildWorkflowExecutionInitiatedEventAttributes().GetWorkflowType().GetName())
+		case temporal_enums.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_FAILED:
+			closeSpan(spans[event.GetStartChildWorkflowExecutionFailedEventAttributes().GetInitiatedEventId()], event, "StartFailed")
+		case temporal_enums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_COMPLETED:
+			closeSpan(spans[event.GetChildWorkflowExecutionCompletedEventAttributes().GetInitiatedEventId()], event, "Completed")
+		case temporal_enums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_FAILED:
+			closeSpan(spans[event.GetChildWorkflowExecutionFailedEventAttributes().GetInitiatedEventId()], event, "Failed")
+		case temporal_enums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_TIMED_OUT:
+			closeSpan(spans[event.GetChildWorkflowExecutionTimedOutEventAttributes().GetInitiatedEventId()], event, "TimedOut")
+		case temporal_enums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_CANCELED:
+			closeSpan(spans[event.GetChildWorkflowExecutionCanceledEventAttributes().GetInitiatedEventId()], event, "Canceled")
+		case temporal_enums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_TERMINATED:
+			closeSpan(spans[event.GetChildWorkflowExecutionTerminatedEventAttributes().GetInitiatedEventId()], event, "Terminated")
+
+		case temporal_enums.EVENT_TYPE_TIMER_STARTED:
+			attrs := event.GetTimerStartedEventAttributes()
+			open(event, spanKindTimer, fmt.Sprintf("timer %s (%s)", attrs.GetTimerId(), attrs.GetStartToFireTimeout().AsDuration()))
+		case temporal_enums.EVENT_TYPE_TIMER_FIRED:
+			closeSpan(spans[event.GetTimerFiredEventAttributes().GetStartedEventId()], event, "Fired")
+		case temporal_enums.EVENT_TYPE_TIMER_CANCELED:
+			closeSpan(spans[event.GetTimerCanceledEventAttributes().GetStartedEventId()], event, "Canceled")
+		}
+	}
+	return root
+}
+
+// end closes the span at the given time
+func (s *traceSpan) end(endTime time.Time) {
+	s.EndTime = &endTime
+	s.Duration = endTime.Sub(s.StartTime).String()
+}
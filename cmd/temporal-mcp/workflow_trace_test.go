@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"github.com/mocksi/temporal-mcp/internal/sanitize_history_event"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/history/v1"
+)
+
+// readTraceFixture reads the activity trace fixture history
+func readTraceFixture(t *testing.T) []*history.HistoryEvent {
+	f, err := os.Open("test_data/activity_trace_history.jsonl")
+	require.NoError(t, err)
+	defer f.Close()
+	events, err := sanitize_history_event.ReadEvents(f)
+	require.NoError(t, err)
+	return events
+}
+
+func TestBuildWorkflowTrace(t *testing.T) {
+	root := buildWorkflowTrace(readTraceFixture(t))
+	require.NotNil(t, root)
+	require.Equal(t, "1", root.SpanID)
+	require.Equal(t, "OrderWorkflow", root.Name)
+	require.Equal(t, spanKindWorkflow, root.Kind)
+	require.Equal(t, "Running", root.Status)
+	require.Nil(t, root.EndTime)
+	require.Len(t, root.Children, 3)
+
+	charge := root.Children[0]
+	require.Equal(t, "5", charge.SpanID)
+	require.Equal(t, root.SpanID, charge.ParentSpanID)
+	require.Equal(t, "ChargeCard", charge.Name)
+	require.Equal(t, spanKindActivity, charge.Kind)
+	require.Equal(t, "Completed", charge.Status)
+	require.Equal(t, int32(2), charge.Attempt)
+	require.Equal(t, time.Date(2025, 5, 2, 10, 0, 1, 0, time.UTC), charge.StartTime)
+	require.Equal(t, "2.5s", charge.Duration)
+
+	timer := root.Children[1]
+	require.Equal(t, spanKindTimer, timer.Kind)
+	require.Equal(t, "timer 6 (10s)", timer.Name)
+	require.Equal(t, "Fired", timer.Status)
+	require.Equal(t, "10s", timer.Duration)
+
+	ship := root.Children[2]
+	require.Equal(t, "ShipOrder", ship.Name)
+	require.Equal(t, root.SpanID, ship.ParentSpanID)
+	require.Equal(t, "Running", ship.Status)
+	require.Nil(t, ship.EndTime, "an activity that hasn't closed has no end")
+	require.Empty(t, ship.Duration)
+
+	require.Nil(t, buildWorkflowTrace(nil), "a history without a start event has no trace")
+}
+
+func TestGetWorkflowTrace(t *testing.T) {
+	mockClient := &mockTemporalClient{historyEvents: readTraceFixture(t)}
+	resp, err := getWorkflowTraceHandler(mockClient, &config.Config{})(GetWorkflowTraceParams{WorkflowID: "order_42", RunID: "run-1"})
+	require.NoError(t, err)
+	require.Len(t, resp.Content, 1)
+
+	var trace workflowTrace
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].TextContent.Text), &trace))
+	require.Equal(t, "order_42", trace.WorkflowID)
+	require.Equal(t, "run-1", trace.RunID)
+	require.Equal(t, "OrderWorkflow", trace.Root.Name)
+	require.Len(t, trace.Root.Children, 3)
+
+	resp, err = asResponse(getWorkflowTraceHandler(nil, &config.Config{})(GetWorkflowTraceParams{WorkflowID: "order_42"}))
+	require.NoError(t, err)
+	require.Contains(t, resp.Content[0].TextContent.Text, "Temporal client is not available")
+}
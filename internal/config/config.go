@@ -1,39 +1,198 @@
 package config
 
 import (
+	"encoding/json"
+	"fmt"
 	"gopkg.in/yaml.v3"
 	"os"
+	"path/filepath"
+	"time"
 )
 
 // Config holds the top-level configuration
 type Config struct {
-	Temporal  TemporalConfig         `yaml:"temporal"`
-	Workflows map[string]WorkflowDef `yaml:"workflows"`
+	Temporal                 TemporalConfig             `yaml:"temporal"`
+	ActiveProfile            string                     `yaml:"activeProfile,omitempty"`
+	SystemPromptStyle        string                     `yaml:"systemPromptStyle,omitempty"`        // "verbose" (default) or "compact"
+	SystemPromptMaxWorkflows int                        `yaml:"systemPromptMaxWorkflows,omitempty"` // 0 means no limit
+	WorkflowIDHashSalt       string                     `yaml:"workflowIDHashSalt,omitempty"`       // Mixed into {{ hash }} in workflow ID recipes
+	WorkflowIDMissingKey     string                     `yaml:"workflowIDMissingKey,omitempty"`     // One of the MissingKey* modes
+	WorkflowIDMissingValue   string                     `yaml:"workflowIDMissingValue,omitempty"`   // Replacement used by MissingKeyReplace
+	WorkflowIDRecipeTimeout  string                     `yaml:"workflowIDRecipeTimeout,omitempty"`  // Max time to render a workflow ID recipe; default 1s
+	MaxWorkflowIDLength      int                        `yaml:"maxWorkflowIDLength,omitempty"`      // Longer IDs are cut and get a hash suffix; default 1000
+	WorkflowIDFallback       string                     `yaml:"workflowIDFallback,omitempty"`       // One of the WorkflowIDFallback* modes
+	MaxToolResponseBytes     int                        `yaml:"maxToolResponseBytes,omitempty"`     // 0 means no limit
+	ToolPrefix               string                     `yaml:"toolPrefix,omitempty"`               // Prepended to every tool name, e.g. "orders_"
+	ResponseEnvelope         bool                       `yaml:"responseEnvelope,omitempty"`         // Wrap tool responses in {"ok", "data", "error"}
+	MaxWorkflows             int                        `yaml:"maxWorkflows,omitempty"`             // Max workflow tools; 0 means the default (500)
+	TruncateWorkflows        bool                       `yaml:"truncateWorkflows,omitempty"`        // Register the first MaxWorkflows instead of failing
+	DiscoverWorkflows        bool                       `yaml:"discoverWorkflows,omitempty"`        // Register tools for workflows started by schedules
+	DefaultRunSelector       string                     `yaml:"defaultRunSelector,omitempty"`       // Run used when a tool call omits runId: one of the RunSelector* values
+	StrictParams             bool                       `yaml:"strictParams,omitempty"`             // Reject params not declared in a workflow's input fields
+	HistoryNotFoundAsEmpty   bool                       `yaml:"historyNotFoundAsEmpty,omitempty"`   // GetWorkflowHistory returns [] for unknown workflows
+	HistorySanitizationStats bool                       `yaml:"historySanitizationStats,omitempty"` // Append payload bytes removed by sanitization to GetWorkflowHistory responses
+	HistoryIncludePayloads   bool                       `yaml:"historyIncludePayloads,omitempty"`   // GetWorkflowHistory keeps payloads instead of sanitizing them
+	EnableBatchTerminate     bool                       `yaml:"enableBatchTerminate,omitempty"`     // Register the destructive BatchTerminate tool
+	HistoryFileDir           string                     `yaml:"historyFileDir,omitempty"`           // Directory AnalyzeHistoryFile reads from
+	LogParams                bool                       `yaml:"logParams,omitempty"`                // Log each execution's params
+	RedactParamKeys          []string                   `yaml:"redactParamKeys,omitempty"`          // Param keys masked when logging params
+	LogParamsUnredacted      bool                       `yaml:"logParamsUnredacted,omitempty"`      // Allow logParams without redactParamKeys
+	CheckTaskQueuePollers    bool                       `yaml:"checkTaskQueuePollers,omitempty"`    // Warn when no worker polls a workflow's task queue
+	ResultRedaction          RedactionDef               `yaml:"resultRedaction,omitempty"`          // Applied to every workflow result
+	MaxWorkflowTimeout       string                     `yaml:"maxWorkflowTimeout,omitempty"`       // Cap on caller-supplied run/execution timeouts
+	WorkerWaitTimeout        string                     `yaml:"workerWaitTimeout,omitempty"`        // How long to wait for a worker before failing a call, e.g. "30s"
+	StartProfiles            map[string]StartProfileDef `yaml:"startProfiles,omitempty"`            // Shared start options workflows reference via startProfile
+	DegradedModeMessage      string                     `yaml:"degradedModeMessage,omitempty"`      // Appended to the errors tools return when Temporal is unavailable
+	SignalArgTemplates       map[string]string          `yaml:"signalArgTemplates,omitempty"`       // Templates SignalWorkflow renders from params into a signal's argument, by signal name
+	Workflows                map[string]WorkflowDef     `yaml:"workflows"`
 }
 
+// Modes for params referenced by a workflow ID recipe but missing from the call
+const (
+	MissingKeyDefault = "default" // render text/template's "<no value>"
+	MissingKeyError   = "error"   // fail the call
+	MissingKeyReplace = "replace" // render WorkflowIDMissingValue (also used for empty values)
+)
+
+// Runs tools use when a call omits the run ID
+const (
+	RunSelectorLatest = "latest" // the latest run (default)
+	RunSelectorFirst  = "first"  // the first run of the chain of runs
+)
+
+// MaxPriority is the lowest workflow priority (highest priority key) Temporal servers accept by default
+const MaxPriority = 5
+
+// How workflow IDs are chosen for workflows without a workflow ID recipe
+const (
+	WorkflowIDFallbackDeterministic = "deterministic" // UUIDv5 of the workflow name and params (default)
+	WorkflowIDFallbackRandom        = "random"        // random UUIDv4; every call starts a new execution
+)
+
+// System prompt styles
+const (
+	SystemPromptStyleVerbose = "verbose"
+	SystemPromptStyleCompact = "compact"
+)
+
 // TemporalConfig defines connection settings for Temporal service
 type TemporalConfig struct {
-	HostPort         string `yaml:"hostPort"`
-	Namespace        string `yaml:"namespace"`
-	Environment      string `yaml:"environment"`
-	Timeout          string `yaml:"timeout,omitempty"`
-	DefaultTaskQueue string `yaml:"defaultTaskQueue,omitempty"`
+	HostPort         string       `yaml:"hostPort"`
+	Namespace        string       `yaml:"namespace"`
+	Environment      string       `yaml:"environment"`
+	Timeout          string       `yaml:"timeout,omitempty"`
+	DefaultTaskQueue string       `yaml:"defaultTaskQueue,omitempty"`
+	RetryOptions     RetryOptions `yaml:"retryOptions,omitempty"`
+	KeepAlive        KeepAlive    `yaml:"keepAlive,omitempty"`
+	MinRetention     string       `yaml:"minRetention,omitempty"`   // Warn at startup if the namespace retains closed workflows for less
+	UIBaseURL        string       `yaml:"uiBaseURL,omitempty"`      // Temporal Web UI, e.g. "http://localhost:8233"; enables run links
+	ClientCertPath   string       `yaml:"clientCertPath,omitempty"` // Client certificate (PEM) for mTLS; enables TLS in any environment
+	ClientKeyPath    string       `yaml:"clientKeyPath,omitempty"`  // Private key (PEM) for ClientCertPath
+	ServerCAPath     string       `yaml:"serverCAPath,omitempty"`   // CA bundle (PEM) to verify the server; system roots if empty
+	ServerName       string       `yaml:"serverName,omitempty"`     // Overrides the TLS server name (SNI) when it differs from hostPort's host
+	APIKey           string       `yaml:"apiKey,omitempty"`         // API key sent as a bearer token; needs TLS (remote, or local with TLS settings)
+}
+
+// KeepAlive configures gRPC keep-alive pings on the Temporal connection. Empty values keep the SDK defaults.
+type KeepAlive struct {
+	Time    string `yaml:"time,omitempty"`    // Idle time before a ping is sent, e.g. "30s"
+	Timeout string `yaml:"timeout,omitempty"` // How long to wait for a ping ack before closing the connection, e.g. "15s"
+}
+
+// RetryOptions configures retries of transient Temporal service errors made by the MCP itself (e.g. while reading
+// workflow histories). Zero values fall back to defaults.
+type RetryOptions struct {
+	InitialInterval    string  `yaml:"initialInterval,omitempty"`
+	MaximumInterval    string  `yaml:"maximumInterval,omitempty"`
+	MaximumAttempts    int     `yaml:"maximumAttempts,omitempty"`
+	BackoffCoefficient float64 `yaml:"backoffCoefficient,omitempty"`
 }
 
 // WorkflowDef describes a Temporal workflow exposed as a tool
 type WorkflowDef struct {
-	Purpose          string       `yaml:"purpose"`
-	Input            ParameterDef `yaml:"input"`
-	Output           ParameterDef `yaml:"output"`
-	TaskQueue        string       `yaml:"taskQueue"`
-	WorkflowIDRecipe string       `yaml:"workflowIDRecipe"`
+	Purpose           string                `yaml:"purpose"`
+	Input             ParameterDef          `yaml:"input"`
+	InputSchemaFile   string                `yaml:"inputSchemaFile,omitempty"` // JSON Schema file (relative to the config file) loaded into Input.Schema
+	Output            ParameterDef          `yaml:"output"`
+	TaskQueue         string                `yaml:"taskQueue"`
+	Namespace         string                `yaml:"namespace,omitempty"` // Overrides temporal.namespace for this workflow
+	TaskQueueRouting  *TaskQueueRoutingDef  `yaml:"taskQueueRouting,omitempty"`
+	WorkflowIDRecipe  string                `yaml:"workflowIDRecipe"`
+	Priority          int                   `yaml:"priority,omitempty"`          // Task priority key, 1 (highest) to MaxPriority; 0 for the server default
+	DefaultForceRerun bool                  `yaml:"defaultForceRerun,omitempty"` // force_rerun used when a call omits it
+	StartProfile      string                `yaml:"startProfile,omitempty"`      // Name of a startProfiles entry supplying unset start options
+	RunTimeout        string                `yaml:"runTimeout,omitempty"`        // Workflow run timeout used when a call omits run_timeout
+	ExecutionTimeout  string                `yaml:"executionTimeout,omitempty"`  // Workflow execution timeout used when a call omits execution_timeout; also bounds the wait for the result
+	RetryPolicy       *RetryOptions         `yaml:"retryPolicy,omitempty"`       // Retry policy for the workflow execution; none if unset
+	FailureQuery      string                `yaml:"failureQuery,omitempty"`      // Query returning partial state when the workflow fails
+	OutputFormat      string                `yaml:"outputFormat,omitempty"`      // json-pretty, csv-table, binary or raw; empty for the default rendering
+	ResultField       string                `yaml:"resultField,omitempty"`       // Dot path of the single result value to return, e.g. "shipment.status"
+	Defaults          map[string]string     `yaml:"defaults,omitempty"`
+	ExampleParams     map[string]any        `yaml:"exampleParams,omitempty"` // Replaces the generated example params in tool docs
+	Profiles          map[string]ProfileDef `yaml:"profiles,omitempty"`
+	ErrorHints        ErrorHintsDef         `yaml:"errorHints,omitempty"`
+	ResultRedaction   RedactionDef          `yaml:"resultRedaction,omitempty"` // Applied on top of the global resultRedaction
+	Discovered        bool                  `yaml:"-"`                         // Registered by workflow discovery rather than config; its params are unknown
+}
+
+// TaskQueueRoutingDef routes a workflow to a task queue chosen by the value of one of its params, e.g. per-region
+// workers. Values without a route use the workflow's TaskQueue (or the default task queue).
+type TaskQueueRoutingDef struct {
+	Param  string            `yaml:"param"`  // Name of the param whose value selects the route
+	Routes map[string]string `yaml:"routes"` // Param value -> task queue
+}
+
+// ErrorHintsDef holds domain-specific guidance appended to a workflow's standard error messages
+type ErrorHintsDef struct {
+	MissingParams string `yaml:"missingParams,omitempty"` // Shown when required parameters are missing
+	Failure       string `yaml:"failure,omitempty"`       // Shown when the workflow fails to start or fails
+}
+
+// RedactionDef masks sensitive values in workflow results before they're returned
+type RedactionDef struct {
+	Patterns []string `yaml:"patterns,omitempty"` // Regular expressions; matches inside any string value are masked
+	Fields   []string `yaml:"fields,omitempty"`   // Dot paths (e.g. "customer.ssn") whose whole value is masked
+}
+
+// StartProfileDef holds workflow start options shared by several workflows. A workflow referencing it via startProfile
+// inherits every option it doesn't set itself.
+type StartProfileDef struct {
+	TaskQueue        string        `yaml:"taskQueue,omitempty"`
+	Priority         int           `yaml:"priority,omitempty"`
+	RunTimeout       string        `yaml:"runTimeout,omitempty"`
+	ExecutionTimeout string        `yaml:"executionTimeout,omitempty"`
+	RetryPolicy      *RetryOptions `yaml:"retryPolicy,omitempty"`
+}
+
+// ProfileDef holds per-environment overrides for a workflow, selected via Config.ActiveProfile
+type ProfileDef struct {
+	Defaults map[string]string `yaml:"defaults,omitempty"`
 }
 
 // ParameterDef defines input/output schema for a workflow
 type ParameterDef struct {
 	Type        string              `yaml:"type"`
 	Fields      []map[string]string `yaml:"fields"`
+	FieldTypes  map[string]string   `yaml:"fieldTypes,omitempty"` // Optional JSON type per field name (string, number, integer, boolean, object, array)
+	Transforms  map[string][]string `yaml:"transforms,omitempty"` // Optional transforms per field name (trim, lower, upper, toEpoch), applied in order
 	Description string              `yaml:"description,omitempty"`
+	Schema      map[string]any      `yaml:"schema,omitempty"` // Optional JSON Schema; on inputs it replaces Fields for validation, on outputs results are checked against it
+
+}
+
+// EffectiveDefaults returns the workflow's default params, with the given profile's defaults layered on top of the
+// base defaults. An empty or unknown profile yields just the base defaults.
+func (w WorkflowDef) EffectiveDefaults(profile string) map[string]string {
+	defaults := make(map[string]string, len(w.Defaults))
+	for k, v := range w.Defaults {
+		defaults[k] = v
+	}
+	if p, ok := w.Profiles[profile]; ok && profile != "" {
+		for k, v := range p.Defaults {
+			defaults[k] = v
+		}
+	}
+	return defaults
 }
 
 // LoadConfig reads and parses YAML config from file
@@ -46,5 +205,146 @@ func LoadConfig(path string) (*Config, error) {
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, err
 	}
+	if err := cfg.applyStartProfiles(); err != nil {
+		return nil, err
+	}
+	if err := cfg.validateWorkflowTimeouts(); err != nil {
+		return nil, err
+	}
+	if err := cfg.validateDurations(); err != nil {
+		return nil, err
+	}
+	if err := cfg.validateRetryPolicies(); err != nil {
+		return nil, err
+	}
+	if err := cfg.validateRunSelector(); err != nil {
+		return nil, err
+	}
+	for name, workflow := range cfg.Workflows {
+		if workflow.InputSchemaFile == "" {
+			continue
+		}
+		schema, err := loadSchemaFile(filepath.Dir(path), workflow.InputSchemaFile)
+		if err != nil {
+			return nil, fmt.Errorf("workflow %s: inputSchemaFile: %w", name, err)
+		}
+		workflow.Input.Schema = schema
+		cfg.Workflows[name] = workflow
+	}
 	return &cfg, nil
 }
+
+// applyStartProfiles fills each workflow's unset start options from the start profile it references
+func (c *Config) applyStartProfiles() error {
+	for name, workflow := range c.Workflows {
+		if workflow.StartProfile == "" {
+			continue
+		}
+		profile, ok := c.StartProfiles[workflow.StartProfile]
+		if !ok {
+			return fmt.Errorf("workflow %s: unknown startProfile %q", name, workflow.StartProfile)
+		}
+		if workflow.TaskQueue == "" {
+			workflow.TaskQueue = profile.TaskQueue
+		}
+		if workflow.Priority == 0 {
+			workflow.Priority = profile.Priority
+		}
+		if workflow.RunTimeout == "" {
+			workflow.RunTimeout = profile.RunTimeout
+		}
+		if workflow.ExecutionTimeout == "" {
+			workflow.ExecutionTimeout = profile.ExecutionTimeout
+		}
+		if workflow.RetryPolicy == nil {
+			workflow.RetryPolicy = profile.RetryPolicy
+		}
+		c.Workflows[name] = workflow
+	}
+	return nil
+}
+
+// validateWorkflowTimeouts checks that the workflows' runTimeout and executionTimeout are positive durations
+func (c *Config) validateWorkflowTimeouts() error {
+	for name, workflow := range c.Workflows {
+		for field, value := range map[string]string{"runTimeout": workflow.RunTimeout, "executionTimeout": workflow.ExecutionTimeout} {
+			if value == "" {
+				continue
+			}
+			if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+				return fmt.Errorf("workflow %s: %s must be a positive duration like \"10m\", got %q", name, field, value)
+			}
+		}
+	}
+	return nil
+}
+
+// validateDurations checks the top-level duration settings, so that a typo fails at startup instead of every tool call
+func (c *Config) validateDurations() error {
+	durations := map[string]string{
+		"workerWaitTimeout":       c.WorkerWaitTimeout,
+		"maxWorkflowTimeout":      c.MaxWorkflowTimeout,
+		"workflowIDRecipeTimeout": c.WorkflowIDRecipeTimeout,
+	}
+	for field, value := range durations {
+		if value == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+			return fmt.Errorf("%s must be a positive duration like \"30s\", got %q", field, value)
+		}
+	}
+	return nil
+}
+
+// validateRetryPolicies checks the retry policies of the workflows (including those inherited from start profiles):
+// intervals must be positive durations, maximumAttempts can't be negative and a set backoffCoefficient must be at
+// least 1, as Temporal requires
+func (c *Config) validateRetryPolicies() error {
+	for name, workflow := range c.Workflows {
+		policy := workflow.RetryPolicy
+		if policy == nil {
+			continue
+		}
+		for field, value := range map[string]string{"initialInterval": policy.InitialInterval, "maximumInterval": policy.MaximumInterval} {
+			if value == "" {
+				continue
+			}
+			if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+				return fmt.Errorf("workflow %s: retryPolicy.%s must be a positive duration like \"1s\", got %q", name, field, value)
+			}
+		}
+		if policy.MaximumAttempts < 0 {
+			return fmt.Errorf("workflow %s: retryPolicy.maximumAttempts can't be negative, got %d", name, policy.MaximumAttempts)
+		}
+		if policy.BackoffCoefficient != 0 && policy.BackoffCoefficient < 1 {
+			return fmt.Errorf("workflow %s: retryPolicy.backoffCoefficient must be at least 1, got %v", name, policy.BackoffCoefficient)
+		}
+	}
+	return nil
+}
+
+// validateRunSelector checks that defaultRunSelector is one of the RunSelector* values
+func (c *Config) validateRunSelector() error {
+	switch c.DefaultRunSelector {
+	case "", RunSelectorLatest, RunSelectorFirst:
+		return nil
+	}
+	return fmt.Errorf("defaultRunSelector must be %q or %q, got %q", RunSelectorLatest, RunSelectorFirst, c.DefaultRunSelector)
+}
+
+// loadSchemaFile reads a JSON Schema document. Relative paths are resolved against dir.
+func loadSchemaFile(dir string, path string) (map[string]any, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("%s is not a JSON Schema document: %w", path, err)
+	}
+	return schema, nil
+}
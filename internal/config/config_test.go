@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -118,3 +119,200 @@ func TestWorkflowInputStructs(t *testing.T) {
 		}
 	}
 }
+
+// TestProfileDefaultsOverrideBase verifies that the active profile's defaults win over the workflow's base defaults
+func TestProfileDefaultsOverrideBase(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "profile_config.yml")
+	configContent := `
+temporal:
+  hostPort: "localhost:7233"
+  namespace: "default"
+  environment: "local"
+activeProfile: "prod"
+workflows:
+  ReportWorkflow:
+    purpose: "Generates a report"
+    input:
+      type: "ReportRequest"
+      fields:
+        - region: "Region to report on"
+        - format: "Optional output format"
+    defaults:
+      region: "us-dev-1"
+      format: "pdf"
+    profiles:
+      prod:
+        defaults:
+          region: "us-east-1"
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if cfg.ActiveProfile != "prod" {
+		t.Fatalf("Expected activeProfile to be prod, got '%s'", cfg.ActiveProfile)
+	}
+
+	defaults := cfg.Workflows["ReportWorkflow"].EffectiveDefaults(cfg.ActiveProfile)
+	if defaults["region"] != "us-east-1" {
+		t.Errorf("Expected prod profile region 'us-east-1', got '%s'", defaults["region"])
+	}
+	if defaults["format"] != "pdf" {
+		t.Errorf("Expected base default format 'pdf' to be kept, got '%s'", defaults["format"])
+	}
+
+	baseDefaults := cfg.Workflows["ReportWorkflow"].EffectiveDefaults("")
+	if baseDefaults["region"] != "us-dev-1" {
+		t.Errorf("Expected base region 'us-dev-1' without a profile, got '%s'", baseDefaults["region"])
+	}
+}
+
+// TestStartProfileInheritance verifies that workflows inherit their start profile's options and can override them
+func TestStartProfileInheritance(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "start_profile_config.yml")
+	configContent := `
+temporal:
+  hostPort: "localhost:7233"
+  namespace: "default"
+  environment: "local"
+startProfiles:
+  standard:
+    taskQueue: "standard-queue"
+    priority: 3
+    runTimeout: "10m"
+    retryPolicy:
+      maximumAttempts: 4
+workflows:
+  ReportWorkflow:
+    purpose: "Generates a report"
+    startProfile: standard
+    taskQueue: "report-queue"
+  InvoiceWorkflow:
+    purpose: "Sends an invoice"
+    startProfile: standard
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	report := cfg.Workflows["ReportWorkflow"]
+	if report.TaskQueue != "report-queue" {
+		t.Errorf("Expected the workflow's own task queue 'report-queue', got '%s'", report.TaskQueue)
+	}
+	if report.Priority != 3 || report.RunTimeout != "10m" {
+		t.Errorf("Expected priority 3 and runTimeout 10m from the profile, got %d and '%s'", report.Priority, report.RunTimeout)
+	}
+	if report.RetryPolicy == nil || report.RetryPolicy.MaximumAttempts != 4 {
+		t.Errorf("Expected the profile's retry policy, got %+v", report.RetryPolicy)
+	}
+
+	if invoice := cfg.Workflows["InvoiceWorkflow"]; invoice.TaskQueue != "standard-queue" {
+		t.Errorf("Expected the profile's task queue 'standard-queue', got '%s'", invoice.TaskQueue)
+	}
+
+	unknown := strings.Replace(configContent, "startProfile: standard\n    taskQueue", "startProfile: missing\n    taskQueue", 1)
+	if err := os.WriteFile(configPath, []byte(unknown), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	if _, err := LoadConfig(configPath); err == nil || !strings.Contains(err.Error(), `unknown startProfile "missing"`) {
+		t.Errorf("Expected unknown startProfile error, got %v", err)
+	}
+}
+
+// TestWorkflowTimeoutsValidatedAtLoad verifies that bad workflow timeouts fail LoadConfig
+func TestWorkflowTimeoutsValidatedAtLoad(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "timeout_config.yml")
+	for _, value := range []string{"soon", "-5m", "0s"} {
+		configContent := `
+temporal:
+  hostPort: "localhost:7233"
+workflows:
+  ReportWorkflow:
+    purpose: "Generates a report"
+    executionTimeout: "` + value + `"
+`
+		if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+			t.Fatalf("Failed to write test config: %v", err)
+		}
+		if _, err := LoadConfig(configPath); err == nil || !strings.Contains(err.Error(), "workflow ReportWorkflow: executionTimeout") {
+			t.Errorf("Expected an executionTimeout error for %q, got %v", value, err)
+		}
+	}
+}
+
+func TestDurationsValidatedAtLoad(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "duration_config.yml")
+	for _, field := range []string{"workerWaitTimeout", "maxWorkflowTimeout", "workflowIDRecipeTimeout"} {
+		for _, value := range []string{"soon", "-5m", "0s"} {
+			configContent := `
+temporal:
+  hostPort: "localhost:7233"
+` + field + `: "` + value + `"
+workflows: {}
+`
+			if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+			if _, err := LoadConfig(configPath); err == nil || !strings.Contains(err.Error(), field+" must be a positive duration") {
+				t.Errorf("Expected a %s error for %q, got %v", field, value, err)
+			}
+		}
+	}
+}
+
+func TestRetryPoliciesValidatedAtLoad(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "retry_config.yml")
+	tests := map[string]string{
+		"initialInterval: \"soon\"": "workflow ReportWorkflow: retryPolicy.initialInterval must be a positive duration",
+		"maximumInterval: \"-1s\"":  "workflow ReportWorkflow: retryPolicy.maximumInterval must be a positive duration",
+		"maximumAttempts: -1":       "workflow ReportWorkflow: retryPolicy.maximumAttempts can't be negative",
+		"backoffCoefficient: 0.5":   "workflow ReportWorkflow: retryPolicy.backoffCoefficient must be at least 1",
+		"initialInterval: \"0s\"":   "workflow ReportWorkflow: retryPolicy.initialInterval must be a positive duration",
+	}
+	for setting, expected := range tests {
+		configContent := `
+temporal:
+  hostPort: "localhost:7233"
+startProfiles:
+  standard:
+    retryPolicy:
+      ` + setting + `
+workflows:
+  ReportWorkflow:
+    purpose: "Generates a report"
+    startProfile: standard
+`
+		if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+			t.Fatalf("Failed to write test config: %v", err)
+		}
+		if _, err := LoadConfig(configPath); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected %q for %s, got %v", expected, setting, err)
+		}
+	}
+}
+
+func TestRunSelectorValidatedAtLoad(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "selector_config.yml")
+	configContent := `
+temporal:
+  hostPort: "localhost:7233"
+defaultRunSelector: "oldest"
+workflows: {}
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	if _, err := LoadConfig(configPath); err == nil || !strings.Contains(err.Error(), `defaultRunSelector must be "latest" or "first", got "oldest"`) {
+		t.Errorf("Expected a defaultRunSelector error, got %v", err)
+	}
+}
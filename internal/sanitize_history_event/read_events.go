@@ -0,0 +1,39 @@
+package sanitize_history_event
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"go.temporal.io/api/history/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// maxEventLineBytes bounds a single line of a history file. Temporal permits histories up to 50mb, so one event can be
+// far larger than bufio.Scanner's default 64kb limit.
+const maxEventLineBytes = 50 * 1024 * 1024
+
+// ReadEvents reads a JSONL history file: one protojson-encoded HistoryEvent per line. Blank lines are skipped.
+func ReadEvents(r io.Reader) ([]*history.HistoryEvent, error) {
+	var events []*history.HistoryEvent
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventLineBytes)
+	line := 0
+	for scanner.Scan() {
+		line++
+		eventJson := scanner.Bytes()
+		if len(eventJson) == 0 {
+			continue
+		}
+		event := &history.HistoryEvent{}
+		if err := protojson.Unmarshal(eventJson, event); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
@@ -1,7 +1,6 @@
 package sanitize_history_event
 
 import (
-	"bufio"
 	"context"
 	"fmt"
 	"github.com/mocksi/temporal-mcp/internal/config"
@@ -98,15 +97,8 @@ func readEvents(t *testing.T, filename string) []*history.HistoryEvent {
 	require.NoError(t, err)
 	defer f.Close()
 
-	var events []*history.HistoryEvent
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		eventJson := scanner.Text()
-		event := &history.HistoryEvent{}
-		err := protojson.Unmarshal([]byte(eventJson), event)
-		require.NoError(t, err)
-		events = append(events, event)
-	}
+	events, err := ReadEvents(f)
+	require.NoError(t, err)
 
 	return events
 }
@@ -0,0 +1,49 @@
+package sanitize_history_event
+
+import (
+	"fmt"
+
+	"go.temporal.io/api/history/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Stats measures how much sanitization shrank a set of history events. Sizes are protobuf wire sizes, which track the
+// JSON sizes closely enough to tune thresholds with.
+type Stats struct {
+	Events         int // events sanitized
+	EventsTouched  int // events that had at least one payload removed
+	OriginalBytes  int
+	SanitizedBytes int
+}
+
+// SanitizeHistoryEventWithStats sanitizes the event like SanitizeHistoryEvent and adds the result to the stats
+func SanitizeHistoryEventWithStats(event *history.HistoryEvent, stats *Stats) {
+	before := proto.Size(event)
+	SanitizeHistoryEvent(event)
+	after := proto.Size(event)
+
+	stats.Events++
+	if after < before {
+		stats.EventsTouched++
+	}
+	stats.OriginalBytes += before
+	stats.SanitizedBytes += after
+}
+
+// BytesRemoved is the total size of the payloads removed
+func (s Stats) BytesRemoved() int {
+	return s.OriginalBytes - s.SanitizedBytes
+}
+
+// Ratio is the sanitized size as a fraction of the original size; 1 when there was nothing to sanitize
+func (s Stats) Ratio() float64 {
+	if s.OriginalBytes == 0 {
+		return 1
+	}
+	return float64(s.SanitizedBytes) / float64(s.OriginalBytes)
+}
+
+func (s Stats) String() string {
+	return fmt.Sprintf("removed %d payload bytes from %d of %d events (%d -> %d bytes, ratio %.2f)",
+		s.BytesRemoved(), s.EventsTouched, s.Events, s.OriginalBytes, s.SanitizedBytes, s.Ratio())
+}
@@ -0,0 +1,37 @@
+package sanitize_history_event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSanitizeHistoryEventWithStats(t *testing.T) {
+	original, sanitized := getTestFilenames("foo")
+	originalEvents := readEvents(t, original)
+	sanitizedEvents := readEvents(t, sanitized)
+
+	expectedRemoved, expectedTouched := 0, 0
+	for i := range originalEvents {
+		removed := proto.Size(originalEvents[i]) - proto.Size(sanitizedEvents[i])
+		expectedRemoved += removed
+		if removed > 0 {
+			expectedTouched++
+		}
+	}
+
+	var stats Stats
+	for _, event := range originalEvents {
+		SanitizeHistoryEventWithStats(event, &stats)
+	}
+
+	require.Equal(t, len(originalEvents), stats.Events)
+	require.Equal(t, expectedTouched, stats.EventsTouched)
+	require.Equal(t, expectedRemoved, stats.BytesRemoved())
+	require.Equal(t, 43, stats.BytesRemoved(), "bytes removed from the foo fixture")
+	require.Less(t, stats.Ratio(), 1.0)
+
+	var empty Stats
+	require.Equal(t, 1.0, empty.Ratio())
+}
@@ -0,0 +1,261 @@
+// Package schema implements the subset of JSON Schema needed to check workflow inputs and outputs: type, enum,
+// properties, required, additionalProperties, items, minimum/maximum, minLength/maxLength and pattern.
+//
+// Values are expected in the shape encoding/json produces when decoding into an interface{} (map[string]any, []any,
+// float64, string, bool, nil). Schemas may come from YAML or JSON, so integer and float keywords are both accepted.
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Violation is one way a value fails a schema
+type Violation struct {
+	Path     []string // Property names and "[i]" array indexes from the root to the offending value; empty for the root
+	Keyword  string   // The schema keyword the value fails, e.g. "type", "required" or "minimum"
+	Property string   // For "required" and "additionalProperties": the missing or unexpected property of the value
+	Message  string
+}
+
+// String renders the violation as its JSON-pointer-like path and message, e.g. "$.items[0].sku: string is shorter than
+// 3 characters"
+func (v Violation) String() string {
+	var path strings.Builder
+	path.WriteString("$")
+	for _, segment := range v.Path {
+		if !strings.HasPrefix(segment, "[") {
+			path.WriteString(".")
+		}
+		path.WriteString(segment)
+	}
+	return path.String() + ": " + v.Message
+}
+
+// Validate checks value against the given JSON Schema and returns its violations. An empty result means the value is
+// valid.
+func Validate(schema map[string]any, value any) []Violation {
+	var violations []Violation
+	validate(schema, value, nil, &violations)
+	return violations
+}
+
+func validate(schema map[string]any, value any, path []string, violations *[]Violation) {
+	report := func(keyword string, property string, format string, args ...any) {
+		*violations = append(*violations, Violation{
+			Path:     path,
+			Keyword:  keyword,
+			Property: property,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+	child := func(segment string) []string {
+		return append(slices.Clip(path), segment)
+	}
+
+	if types := schemaTypes(schema["type"]); len(types) > 0 {
+		matched := false
+		for _, t := range types {
+			if hasType(value, t) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			report("type", "", "expected %s, got %s", joinTypes(types), typeName(value))
+			// Nothing below is meaningful for a value of the wrong type
+			return
+		}
+	}
+
+	if enum, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, allowed := range enum {
+			if equal(allowed, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			report("enum", "", "value %v is not one of %v", value, enum)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		properties, _ := toObject(schema["properties"])
+		if required, ok := schema["required"].([]any); ok {
+			for _, r := range required {
+				name, _ := r.(string)
+				if _, present := v[name]; !present {
+					report("required", name, "missing required property %q", name)
+				}
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if propSchema, ok := toObject(properties[k]); ok {
+				validate(propSchema, v[k], child(k), violations)
+			} else if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+				report("additionalProperties", k, "unexpected property %q", k)
+			} else if additionalSchema, ok := toObject(schema["additionalProperties"]); ok {
+				validate(additionalSchema, v[k], child(k), violations)
+			}
+		}
+	case []any:
+		if itemSchema, ok := toObject(schema["items"]); ok {
+			for i, item := range v {
+				validate(itemSchema, item, child(fmt.Sprintf("[%d]", i)), violations)
+			}
+		}
+	case string:
+		length := utf8.RuneCountInString(v)
+		if min, ok := toNumber(schema["minLength"]); ok && float64(length) < min {
+			report("minLength", "", "string is shorter than %v characters", min)
+		}
+		if max, ok := toNumber(schema["maxLength"]); ok && float64(length) > max {
+			report("maxLength", "", "string is longer than %v characters", max)
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				report("pattern", "", "invalid pattern %q in schema: %v", pattern, err)
+			} else if !re.MatchString(v) {
+				report("pattern", "", "string %q does not match pattern %q", v, pattern)
+			}
+		}
+	default:
+		if n, ok := toNumber(value); ok {
+			if min, ok := toNumber(schema["minimum"]); ok && n < min {
+				report("minimum", "", "%v is less than the minimum %v", n, min)
+			}
+			if max, ok := toNumber(schema["maximum"]); ok && n > max {
+				report("maximum", "", "%v is greater than the maximum %v", n, max)
+			}
+		}
+	}
+}
+
+// schemaTypes normalizes the "type" keyword, which may be a single type or a list of types
+func schemaTypes(t any) []string {
+	switch tt := t.(type) {
+	case string:
+		return []string{tt}
+	case []any:
+		types := make([]string, 0, len(tt))
+		for _, item := range tt {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func hasType(value any, t string) bool {
+	switch t {
+	case "null":
+		return value == nil
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := toNumber(value)
+		return ok
+	case "integer":
+		n, ok := toNumber(value)
+		return ok && n == math.Trunc(n)
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	}
+	// Unknown types don't constrain the value
+	return true
+}
+
+func typeName(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	}
+	if _, ok := toNumber(value); ok {
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func joinTypes(types []string) string {
+	if len(types) == 1 {
+		return types[0]
+	}
+	return fmt.Sprintf("one of %v", types)
+}
+
+// toObject accepts the map types produced by both encoding/json and yaml.v3
+func toObject(v any) (map[string]any, bool) {
+	m, ok := v.(map[string]any)
+	return m, ok
+}
+
+// toNumber accepts the numeric types produced by both encoding/json and yaml.v3
+func toNumber(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// equal reports whether two values are the same JSON value. Objects and arrays are compared through their JSON
+// encodings (encoding/json sorts object keys), which also equates the integers yaml.v3 produces with float64s.
+func equal(a any, b any) bool {
+	if an, ok := toNumber(a); ok {
+		bn, ok := toNumber(b)
+		return ok && an == bn
+	}
+	switch a.(type) {
+	case map[string]any, []any:
+		aj, aErr := json.Marshal(a)
+		bj, bErr := json.Marshal(b)
+		return aErr == nil && bErr == nil && bytes.Equal(aj, bj)
+	}
+	switch b.(type) {
+	case map[string]any, []any:
+		return false
+	}
+	return a == b
+}
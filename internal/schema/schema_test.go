@@ -0,0 +1,133 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// mustDecodeJSON decodes a JSON document the way workflow results and params are decoded
+func mustDecodeJSON(t *testing.T, doc string) any {
+	var v any
+	require.NoError(t, json.Unmarshal([]byte(doc), &v))
+	return v
+}
+
+func TestValidate(t *testing.T) {
+	// Schemas usually come from YAML config, so build the test schema the same way
+	var orderSchema map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(`
+type: object
+required: [orderId, total, items]
+additionalProperties: false
+properties:
+  orderId:
+    type: string
+    pattern: "^ord-[0-9]+$"
+  total:
+    type: number
+    minimum: 0
+  status:
+    enum: [open, shipped]
+  items:
+    type: array
+    items:
+      type: object
+      required: [sku]
+      properties:
+        sku: {type: string, minLength: 3}
+        quantity: {type: integer, maximum: 10}
+`), &orderSchema))
+
+	tests := map[string]struct {
+		value    string
+		expected []string
+	}{
+		"valid": {
+			value: `{"orderId": "ord-1", "total": 12.5, "status": "open", "items": [{"sku": "abc", "quantity": 2}]}`,
+		},
+		"wrong root type": {
+			value:    `"just a string"`,
+			expected: []string{"$: expected object, got string"},
+		},
+		"missing and unexpected properties": {
+			value:    `{"orderId": "ord-1", "items": [], "note": "hi"}`,
+			expected: []string{`$: missing required property "total"`, `$: unexpected property "note"`},
+		},
+		"nested violations": {
+			value: `{"orderId": "order-1", "total": -1, "status": "lost", "items": [{"sku": "ab", "quantity": 2.5}, {}]}`,
+			expected: []string{
+				`$.items[0].quantity: expected integer, got number`,
+				`$.items[0].sku: string is shorter than 3 characters`,
+				`$.items[1]: missing required property "sku"`,
+				`$.orderId: string "order-1" does not match pattern "^ord-[0-9]+$"`,
+				`$.status: value lost is not one of [open shipped]`,
+				`$.total: -1 is less than the minimum 0`,
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var messages []string
+			for _, violation := range Validate(orderSchema, mustDecodeJSON(t, tc.value)) {
+				messages = append(messages, violation.String())
+			}
+			require.Equal(t, tc.expected, messages)
+		})
+	}
+}
+
+func TestViolationFields(t *testing.T) {
+	var orderSchema map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(`
+type: object
+required: [orderId]
+additionalProperties: false
+properties:
+  items:
+    type: array
+    items: {type: object, properties: {sku: {type: string}}}
+`), &orderSchema))
+
+	violations := Validate(orderSchema, mustDecodeJSON(t, `{"items": [{"sku": 7}], "note": "hi"}`))
+	require.Equal(t, []Violation{
+		{Keyword: "required", Property: "orderId", Message: `missing required property "orderId"`},
+		{Path: []string{"items", "[0]", "sku"}, Keyword: "type", Message: "expected string, got number"},
+		{Keyword: "additionalProperties", Property: "note", Message: `unexpected property "note"`},
+	}, violations)
+	require.Equal(t, "$.items[0].sku: expected string, got number", violations[1].String())
+}
+
+func TestValidateEnumOfObjectsAndArrays(t *testing.T) {
+	var schema map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(`
+enum:
+  - {kind: box, size: 2}
+  - [1, 2]
+  - open
+`), &schema))
+
+	tests := map[string]struct {
+		value string
+		valid bool
+	}{
+		"matching object":       {value: `{"size": 2, "kind": "box"}`, valid: true},
+		"object with other key": {value: `{"kind": "box", "size": 3}`},
+		"matching array":        {value: `[1, 2]`, valid: true},
+		"array in other order":  {value: `[2, 1]`},
+		"matching string":       {value: `"open"`, valid: true},
+		"object against scalar": {value: `{"open": true}`},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			violations := Validate(schema, mustDecodeJSON(t, tc.value))
+			if tc.valid {
+				require.Empty(t, violations)
+			} else {
+				require.Len(t, violations, 1)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package temporal
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"log"
 	"os"
@@ -12,13 +15,33 @@ import (
 
 // NewTemporalClient creates a Temporal client based on the provided configuration
 func NewTemporalClient(cfg config.TemporalConfig) (client.Client, error) {
-	// Validate timeout format if specified
-	if cfg.Timeout != "" {
-		_, err := time.ParseDuration(cfg.Timeout)
-		if err != nil {
-			return nil, fmt.Errorf("invalid timeout format: %w", err)
-		}
-		// Note: We're only validating the format, actual timeout handling would be implemented here
+	options, err := buildClientOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bound the initial connection so an unreachable host fails fast instead of waiting on the SDK default
+	ctx := context.Background()
+	if timeout, _ := dialTimeout(cfg); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// Create the client
+	temporalClient, err := client.DialContext(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Temporal client: %w", err)
+	}
+
+	return temporalClient, nil
+}
+
+// buildClientOptions validates the configuration and translates it into client options
+func buildClientOptions(cfg config.TemporalConfig) (client.Options, error) {
+	// Validate timeout format if specified; NewTemporalClient applies it to the dial
+	if _, err := dialTimeout(cfg); err != nil {
+		return client.Options{}, err
 	}
 
 	// Configure a logger that uses stderr
@@ -34,23 +57,106 @@ func NewTemporalClient(cfg config.TemporalConfig) (client.Client, error) {
 		Logger:    temporalLogger,
 	}
 
+	// Keep-alive pings detect connections silently dropped by load balancers while the server is idle
+	if cfg.KeepAlive.Time != "" {
+		keepAliveTime, err := time.ParseDuration(cfg.KeepAlive.Time)
+		if err != nil {
+			return client.Options{}, fmt.Errorf("invalid keepAlive.time: %w", err)
+		}
+		options.ConnectionOptions.KeepAliveTime = keepAliveTime
+	}
+	if cfg.KeepAlive.Timeout != "" {
+		keepAliveTimeout, err := time.ParseDuration(cfg.KeepAlive.Timeout)
+		if err != nil {
+			return client.Options{}, fmt.Errorf("invalid keepAlive.timeout: %w", err)
+		}
+		options.ConnectionOptions.KeepAliveTimeout = keepAliveTimeout
+	}
+
 	// Handle environment-specific configuration
 	switch cfg.Environment {
 	case "local":
-		// Local Temporal server (default settings)
+		// Local Temporal servers are plaintext by default; TLS settings, e.g. for a self-hosted cluster with mTLS, are
+		// applied whenever they are set rather than silently dropped
+		if usesTLS(cfg) {
+			if err := configureTLS(&options, cfg); err != nil {
+				return client.Options{}, err
+			}
+		}
 	case "remote":
-		// To be implemented for remote/cloud Temporal connections
-		// This would include TLS and authentication setup
-		return nil, fmt.Errorf("remote environment configuration not implemented yet")
+		// Remote/cloud Temporal servers are always reached over TLS and need a credential
+		if err := configureTLS(&options, cfg); err != nil {
+			return client.Options{}, err
+		}
+		if options.Credentials == nil {
+			return client.Options{}, fmt.Errorf("remote environment requires apiKey or clientCertPath and clientKeyPath")
+		}
 	default:
-		return nil, fmt.Errorf("unsupported environment type: %s", cfg.Environment)
+		return client.Options{}, fmt.Errorf("unsupported environment type: %s", cfg.Environment)
 	}
 
-	// Create the client
-	temporalClient, err := client.Dial(options)
+	return options, nil
+}
+
+// dialTimeout returns the configured connection timeout, or 0 when none is set
+func dialTimeout(cfg config.TemporalConfig) (time.Duration, error) {
+	if cfg.Timeout == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(cfg.Timeout)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Temporal client: %w", err)
+		return 0, fmt.Errorf("invalid timeout format: %w", err)
 	}
+	return timeout, nil
+}
 
-	return temporalClient, nil
+// usesTLS reports whether any TLS setting (client certificate, CA or server name) is configured
+func usesTLS(cfg config.TemporalConfig) bool {
+	return cfg.ClientCertPath != "" || cfg.ClientKeyPath != "" || cfg.ServerCAPath != "" || cfg.ServerName != ""
+}
+
+// configureTLS enables TLS on the connection and sets the API key or client certificate, if any, as credentials
+func configureTLS(options *client.Options, cfg config.TemporalConfig) error {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: cfg.ServerName}
+
+	if cfg.ServerCAPath != "" {
+		caPEM, err := os.ReadFile(cfg.ServerCAPath)
+		if err != nil {
+			return fmt.Errorf("failed to read serverCAPath: %w", err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(caPEM) {
+			return fmt.Errorf("serverCAPath %s contains no PEM certificates", cfg.ServerCAPath)
+		}
+		tlsConfig.RootCAs = roots
+	}
+
+	if cfg.ClientCertPath != "" && cfg.ClientKeyPath == "" {
+		return fmt.Errorf("clientCertPath is set without clientKeyPath; mTLS needs both the certificate and its private key")
+	}
+	if cfg.ClientKeyPath != "" && cfg.ClientCertPath == "" {
+		return fmt.Errorf("clientKeyPath is set without clientCertPath; mTLS needs both the certificate and its private key")
+	}
+
+	switch {
+	case cfg.APIKey != "":
+		options.Credentials = client.NewAPIKeyStaticCredentials(cfg.APIKey)
+		if cfg.ClientCertPath != "" {
+			// Some deployments want both; the certificate is then presented alongside the API key
+			certificate, err := tls.LoadX509KeyPair(cfg.ClientCertPath, cfg.ClientKeyPath)
+			if err != nil {
+				return fmt.Errorf("failed to load client certificate: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{certificate}
+		}
+	case cfg.ClientCertPath != "":
+		certificate, err := tls.LoadX509KeyPair(cfg.ClientCertPath, cfg.ClientKeyPath)
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		options.Credentials = client.NewMTLSCredentials(certificate)
+	}
+
+	options.ConnectionOptions.TLS = tlsConfig
+	return nil
 }
@@ -2,6 +2,15 @@ package temporal
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -69,8 +78,33 @@ func TestNewTemporalClient(t *testing.T) {
 		}
 	})
 
-	// Test remote environment (which is not implemented yet)
+	// Test remote environment: the connection is made over TLS with the API key as credentials
 	t.Run("RemoteEnvironment", func(t *testing.T) {
+		cfg := config.TemporalConfig{
+			HostPort:    "localhost:12345",
+			Namespace:   "test-namespace",
+			Environment: "remote",
+			APIKey:      "test-api-key",
+			ServerName:  "test.tmprl.cloud",
+		}
+
+		options, err := buildClientOptions(cfg)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if options.ConnectionOptions.TLS == nil {
+			t.Fatal("Expected TLS to be enabled")
+		}
+		if options.ConnectionOptions.TLS.ServerName != "test.tmprl.cloud" {
+			t.Errorf("Expected server name 'test.tmprl.cloud', got '%s'", options.ConnectionOptions.TLS.ServerName)
+		}
+		if options.Credentials == nil {
+			t.Error("Expected API key credentials")
+		}
+	})
+
+	// Test remote environment without credentials
+	t.Run("RemoteEnvironmentWithoutCredentials", func(t *testing.T) {
 		cfg := config.TemporalConfig{
 			HostPort:    "test.tmprl.cloud:7233",
 			Namespace:   "test-namespace",
@@ -78,12 +112,36 @@ func TestNewTemporalClient(t *testing.T) {
 		}
 
 		_, err := NewTemporalClient(cfg)
-		if err == nil {
-			t.Error("Expected error for unimplemented remote environment, got nil")
+		if err == nil || !strings.Contains(err.Error(), "requires apiKey") {
+			t.Errorf("Expected missing credentials error, got %v", err)
 		}
 	})
 }
 
+// TestNewTemporalClientDialTimeout tests that the configured timeout bounds connecting to an unreachable host
+func TestNewTemporalClientDialTimeout(t *testing.T) {
+	if testing.Short() {
+		t.Skip("dials a blackhole address")
+	}
+	cfg := config.TemporalConfig{
+		HostPort:    "10.255.255.1:7233", // Non-routable; connection attempts hang until they time out
+		Namespace:   "default",
+		Environment: "local",
+		Timeout:     "1s",
+	}
+
+	start := time.Now()
+	client, err := NewTemporalClient(cfg)
+	elapsed := time.Since(start)
+	if err == nil {
+		client.Close()
+		t.Fatal("Expected a connection error for a blackhole address, got nil")
+	}
+	if elapsed > 3*time.Second {
+		t.Errorf("Expected the dial to abort after about 1s, took %v", elapsed)
+	}
+}
+
 // MockWorkflowClient is a mock implementation of the Temporal client for testing
 type MockWorkflowClient struct {
 	lastWorkflowName string
@@ -267,3 +325,191 @@ func TestWorkflowExecution(t *testing.T) {
 		})
 	}
 }
+
+// TestBuildClientOptionsKeepAlive tests that keep-alive settings are mapped to the connection options
+func TestBuildClientOptionsKeepAlive(t *testing.T) {
+	cfg := config.TemporalConfig{
+		HostPort:    "localhost:7233",
+		Namespace:   "default",
+		Environment: "local",
+		KeepAlive:   config.KeepAlive{Time: "30s", Timeout: "15s"},
+	}
+
+	options, err := buildClientOptions(cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if options.ConnectionOptions.KeepAliveTime != 30*time.Second {
+		t.Errorf("Expected KeepAliveTime 30s, got %v", options.ConnectionOptions.KeepAliveTime)
+	}
+	if options.ConnectionOptions.KeepAliveTimeout != 15*time.Second {
+		t.Errorf("Expected KeepAliveTimeout 15s, got %v", options.ConnectionOptions.KeepAliveTimeout)
+	}
+
+	// Unset values keep the SDK defaults
+	cfg.KeepAlive = config.KeepAlive{}
+	options, err = buildClientOptions(cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if options.ConnectionOptions.KeepAliveTime != 0 || options.ConnectionOptions.KeepAliveTimeout != 0 {
+		t.Errorf("Expected zero keep-alive options, got %+v", options.ConnectionOptions)
+	}
+
+	cfg.KeepAlive = config.KeepAlive{Time: "often"}
+	if _, err := buildClientOptions(cfg); err == nil {
+		t.Error("Expected error for invalid keepAlive.time, got nil")
+	}
+}
+
+// writeTestCertificate writes a self-signed certificate and its key as PEM files and returns their paths
+func writeTestCertificate(t *testing.T) (certPath, keyPath string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "temporal-mcp-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	dir := t.TempDir()
+	certPath = filepath.Join(dir, "client.pem")
+	keyPath = filepath.Join(dir, "client.key")
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certPath, keyPath
+}
+
+// TestBuildClientOptionsRemote tests that remote configs enable TLS and carry the configured credential
+func TestBuildClientOptionsRemote(t *testing.T) {
+	certPath, keyPath := writeTestCertificate(t)
+	base := config.TemporalConfig{
+		HostPort:    "test.tmprl.cloud:7233",
+		Namespace:   "test-namespace",
+		Environment: "remote",
+	}
+
+	t.Run("APIKey", func(t *testing.T) {
+		cfg := base
+		cfg.APIKey = "test-api-key"
+		options, err := buildClientOptions(cfg)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if options.ConnectionOptions.TLS == nil {
+			t.Error("Expected TLS to be enabled")
+		}
+		if options.Credentials == nil {
+			t.Error("Expected API key credentials")
+		}
+	})
+
+	t.Run("ClientCertificate", func(t *testing.T) {
+		cfg := base
+		cfg.ClientCertPath, cfg.ClientKeyPath, cfg.ServerCAPath = certPath, keyPath, certPath
+		options, err := buildClientOptions(cfg)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if options.ConnectionOptions.TLS == nil || options.ConnectionOptions.TLS.RootCAs == nil {
+			t.Errorf("Expected TLS with the configured CA, got %+v", options.ConnectionOptions.TLS)
+		}
+		if options.Credentials == nil {
+			t.Error("Expected client certificate credentials")
+		}
+	})
+
+	t.Run("CertificateWithoutKey", func(t *testing.T) {
+		cfg := base
+		cfg.ClientCertPath = certPath
+		if _, err := buildClientOptions(cfg); err == nil || !strings.Contains(err.Error(), "clientCertPath is set without clientKeyPath") {
+			t.Errorf("Expected error for certificate without key, got %v", err)
+		}
+	})
+
+	t.Run("KeyWithoutCertificate", func(t *testing.T) {
+		cfg := base
+		cfg.ClientKeyPath = keyPath
+		if _, err := buildClientOptions(cfg); err == nil || !strings.Contains(err.Error(), "clientKeyPath is set without clientCertPath") {
+			t.Errorf("Expected error for key without certificate, got %v", err)
+		}
+	})
+
+	t.Run("ServerName", func(t *testing.T) {
+		cfg := base
+		cfg.ClientCertPath, cfg.ClientKeyPath, cfg.ServerCAPath = certPath, keyPath, certPath
+		cfg.ServerName = "temporal.internal"
+		options, err := buildClientOptions(cfg)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if options.ConnectionOptions.TLS.ServerName != "temporal.internal" {
+			t.Errorf("Expected SNI override 'temporal.internal', got '%s'", options.ConnectionOptions.TLS.ServerName)
+		}
+	})
+
+	t.Run("InvalidCA", func(t *testing.T) {
+		cfg := base
+		cfg.APIKey = "test-api-key"
+		cfg.ServerCAPath = keyPath
+		if _, err := buildClientOptions(cfg); err == nil {
+			t.Error("Expected error for a CA file without certificates, got nil")
+		}
+	})
+
+	t.Run("LocalUnchanged", func(t *testing.T) {
+		cfg := base
+		cfg.Environment = "local"
+		cfg.APIKey = "ignored"
+		options, err := buildClientOptions(cfg)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if options.ConnectionOptions.TLS != nil || options.Credentials != nil {
+			t.Error("Expected local connections to stay plaintext without credentials")
+		}
+	})
+
+	t.Run("LocalWithClientCertificate", func(t *testing.T) {
+		cfg := base
+		cfg.Environment = "local"
+		cfg.ClientCertPath, cfg.ClientKeyPath, cfg.ServerCAPath = certPath, keyPath, certPath
+		cfg.ServerName = "temporal.internal"
+		options, err := buildClientOptions(cfg)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if options.ConnectionOptions.TLS == nil || options.ConnectionOptions.TLS.RootCAs == nil {
+			t.Fatalf("Expected the TLS settings of a local self-hosted cluster to be applied, got %+v", options.ConnectionOptions.TLS)
+		}
+		if options.ConnectionOptions.TLS.ServerName != "temporal.internal" {
+			t.Errorf("Expected SNI override 'temporal.internal', got '%s'", options.ConnectionOptions.TLS.ServerName)
+		}
+		if options.Credentials == nil {
+			t.Error("Expected client certificate credentials")
+		}
+
+		cfg.ClientKeyPath = ""
+		if _, err := buildClientOptions(cfg); err == nil || !strings.Contains(err.Error(), "clientCertPath is set without clientKeyPath") {
+			t.Errorf("Expected error for certificate without key, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package temporal
+
+import (
+	"sync"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"go.temporal.io/sdk/client"
+)
+
+// connectionKey holds the settings that determine a Temporal connection. Configs with the same key can share a client;
+// settings that only affect how the MCP uses a client (task queues, retries, ...) are left out.
+type connectionKey struct {
+	HostPort       string
+	Namespace      string
+	Environment    string
+	Timeout        string
+	KeepAlive      config.KeepAlive
+	ClientCertPath string
+	ClientKeyPath  string
+	ServerCAPath   string
+	ServerName     string
+	APIKey         string
+}
+
+func newConnectionKey(cfg config.TemporalConfig) connectionKey {
+	return connectionKey{
+		HostPort:       cfg.HostPort,
+		Namespace:      cfg.Namespace,
+		Environment:    cfg.Environment,
+		Timeout:        cfg.Timeout,
+		KeepAlive:      cfg.KeepAlive,
+		ClientCertPath: cfg.ClientCertPath,
+		ClientKeyPath:  cfg.ClientKeyPath,
+		ServerCAPath:   cfg.ServerCAPath,
+		ServerName:     cfg.ServerName,
+		APIKey:         cfg.APIKey,
+	}
+}
+
+// pooledClient is a client, or the error creating it, so a connection that failed isn't redialed for every workflow
+type pooledClient struct {
+	client client.Client
+	err    error
+}
+
+// ClientPool creates one Temporal client per distinct connection (host, namespace and connection settings) and hands
+// the same client to every caller that asks for that connection. It is safe for concurrent use.
+type ClientPool struct {
+	mu      sync.Mutex
+	dial    func(cfg config.TemporalConfig) (client.Client, error)
+	clients map[connectionKey]pooledClient
+}
+
+// NewClientPool returns an empty pool that connects with NewTemporalClient
+func NewClientPool() *ClientPool {
+	return &ClientPool{dial: NewTemporalClient, clients: map[connectionKey]pooledClient{}}
+}
+
+// Get returns the pool's client for the connection described by cfg, connecting on first use. A failed connection
+// is not retried; its error is returned to every caller.
+func (p *ClientPool) Get(cfg config.TemporalConfig) (client.Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := newConnectionKey(cfg)
+	if pooled, ok := p.clients[key]; ok {
+		return pooled.client, pooled.err
+	}
+	c, err := p.dial(cfg)
+	p.clients[key] = pooledClient{client: c, err: err}
+	return c, err
+}
+
+// Close closes every client the pool created, once each
+func (p *ClientPool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key, pooled := range p.clients {
+		if pooled.client != nil {
+			pooled.client.Close()
+		}
+		delete(p.clients, key)
+	}
+}
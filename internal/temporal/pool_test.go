@@ -0,0 +1,79 @@
+package temporal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mocksi/temporal-mcp/internal/config"
+	"go.temporal.io/sdk/client"
+)
+
+// fakeClient is a client.Client that only counts Close calls
+type fakeClient struct {
+	client.Client
+	namespace string
+	closed    int
+}
+
+func (c *fakeClient) Close() {
+	c.closed++
+}
+
+func TestClientPool(t *testing.T) {
+	var dialed []*fakeClient
+	dials := 0
+	pool := NewClientPool()
+	pool.dial = func(cfg config.TemporalConfig) (client.Client, error) {
+		dials++
+		if cfg.HostPort == "unreachable:7233" {
+			return nil, errors.New("connection refused")
+		}
+		c := &fakeClient{namespace: cfg.Namespace}
+		dialed = append(dialed, c)
+		return c, nil
+	}
+
+	base := config.TemporalConfig{HostPort: "localhost:7233", Namespace: "default", Environment: "local", DefaultTaskQueue: "orders"}
+	// The connections of three workflows: two in the default namespace (one with another task queue), one in its own
+	orders := base
+	reports := base
+	reports.DefaultTaskQueue = "reports"
+	payments := base
+	payments.Namespace = "payments"
+
+	ordersClient, err := pool.Get(orders)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reportsClient, _ := pool.Get(reports)
+	paymentsClient, _ := pool.Get(payments)
+
+	if ordersClient != reportsClient {
+		t.Error("workflows in the same namespace should share a client")
+	}
+	if paymentsClient == ordersClient {
+		t.Error("a workflow in another namespace should get its own client")
+	}
+	if len(dialed) != 2 || paymentsClient.(*fakeClient).namespace != "payments" {
+		t.Fatalf("expected one client per namespace, dialed %d", len(dialed))
+	}
+
+	// A failed connection is reported to every caller without redialing
+	unreachable := base
+	unreachable.HostPort = "unreachable:7233"
+	for i := 0; i < 2; i++ {
+		if c, err := pool.Get(unreachable); err == nil || c != nil {
+			t.Errorf("expected a connection error, got client %v and error %v", c, err)
+		}
+	}
+	if dials != 3 {
+		t.Errorf("expected 3 dials, got %d", dials)
+	}
+
+	pool.Close()
+	for _, c := range dialed {
+		if c.closed != 1 {
+			t.Errorf("client for namespace %s closed %d times, want once", c.namespace, c.closed)
+		}
+	}
+}